@@ -0,0 +1,85 @@
+package sdk
+
+import (
+	"context"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+// ClientAPI is the interface of Client public methods. Code depending on ClientAPI instead of *Client
+// can be tested without a node, e.g. with the in-memory fake chain of the sdktest package
+type ClientAPI interface {
+	// Lifecycle
+	Close() error
+	Health(ctx context.Context) (*Health, error)
+	Ping(ctx context.Context) error
+
+	// Signers
+	GetSignerAddresses() []string
+	AddSigner(name, mnemonic string) (string, error)
+	AddSignerWithOptions(name, mnemonic string, opts KeyOptions) (string, error)
+	AddSignersFromMnemonic(namePrefix, mnemonic string, count uint32, opts KeyOptions) ([]SignerInfo, error)
+	AddSignerFromPrivKey(name, privKeyHex string) (string, error)
+	AddSignerFromArmor(name, armor, passphrase string) (string, error)
+	AddSignerFromKeystore(name string, keystoreJSON []byte, passphrase string) (string, error)
+	AddExternalSigner(name string, key Signer) (string, error)
+	ReplaceSigner(name, mnemonic string) (string, error)
+	ReplaceSignerWithOptions(name, mnemonic string, opts KeyOptions) (string, error)
+	ReplaceExternalSigner(name string, key Signer) (string, error)
+	RemoveSigner(name string) error
+	ListSigners() []SignerInfo
+	GetSigner(name string) (SignerInfo, error)
+	GetSignerPubKey(name string) (cryptotypes.PubKey, error)
+	GetSignerByEVMAddress(evmAddress string) (SignerInfo, error)
+	SignArbitrary(ctx context.Context, signerName string, data []byte) (legacytx.StdSignature, error)
+
+	// Txs
+	Execute(ctx context.Context, signerName, contractAddress, msg string) (*txtypes.BroadcastTxResponse, error)
+	ExecuteJSON(ctx context.Context, signerName, contractAddress string, msg interface{}) (*txtypes.BroadcastTxResponse, error)
+	Instantiate(ctx context.Context, signerName string, codeID uint64, label, instantiateMsg string, funds []sdktypes.Coin) (*txtypes.BroadcastTxResponse, error)
+	InstantiateJSON(ctx context.Context, signerName string, codeID uint64, label string, instantiateMsg interface{}, funds []sdktypes.Coin) (*txtypes.BroadcastTxResponse, error)
+	BuildUnsigned(ctx context.Context, fromAddress string, msgs ...sdktypes.Msg) (*OfflineTx, error)
+	SignOffline(ctx context.Context, signerName string, unsignedTx []byte, accountNumber, sequence uint64) (*OfflineTx, error)
+	BroadcastSigned(ctx context.Context, signedTx []byte) (*txtypes.BroadcastTxResponse, error)
+	BuildUnsignedMultisig(ctx context.Context, multisigPubKey cryptotypes.PubKey, msgs ...sdktypes.Msg) (*OfflineTx, error)
+	MultisigSignDoc(unsigned *OfflineTx) ([]byte, error)
+	SignMultisig(ctx context.Context, signerName string, multisigPubKey cryptotypes.PubKey, unsigned *OfflineTx) ([]byte, error)
+	CombineMultisig(multisigPubKey cryptotypes.PubKey, unsigned *OfflineTx, signatures ...[]byte) (*OfflineTx, error)
+
+	// Tx lookups and history
+	GetTxByHash(ctx context.Context, txHash string, retries uint, sleepInterval time.Duration) (*coretypes.ResultTx, error)
+	GetTxMetaResponseByHash(ctx context.Context, txHash string, retries uint, sleepInterval time.Duration) (*txtypes.GetTxResponse, error)
+	DecodeTx(tx *coretypes.ResultTx) (*TxRecord, error)
+	HandleTxsByHeight(ctx context.Context, contractAddress string, heightFrom, heightTo int64, acknowledge func(ctx context.Context, msg []abci.Event) error) error
+	HandleDecodedTxsByHeight(ctx context.Context, contractAddress string, heightFrom, heightTo int64, acknowledge func(ctx context.Context, tx *TxRecord) error) error
+	HandleTxsByRange(ctx context.Context, cfg TxSearchConfig, handle func(ctx context.Context, tx *coretypes.ResultTx) error) error
+	ScanTxs(ctx context.Context, cfg ScanConfig, handle func(ctx context.Context, tx *coretypes.ResultTx) error) error
+
+	// Blocks
+	GetLatestHeight(ctx context.Context) (int64, error)
+	GetBlock(ctx context.Context, height int64) (*BlockData, error)
+	FollowBlocks(ctx context.Context, cfg FollowConfig, handle func(ctx context.Context, block *BlockData) error) error
+
+	// Queries
+	GetBankBalance(ctx context.Context, address, denom string) (*banktypes.QueryBalanceResponse, error)
+	FetchContractInfo(ctx context.Context, address string) (*wasmtypes.QueryContractInfoResponse, error)
+	FetchContractHistory(ctx context.Context, address string, pagination *query.PageRequest) (*wasmtypes.QueryContractHistoryResponse, error)
+	FetchContractsByCode(ctx context.Context, codeID uint64, pagination *query.PageRequest) (*wasmtypes.QueryContractsByCodeResponse, error)
+	FetchAllContractsState(ctx context.Context, address string, pagination *query.PageRequest) (*wasmtypes.QueryAllContractStateResponse, error)
+	RawContractState(ctx context.Context, contractAddress string, queryData []byte) (*wasmtypes.QueryRawContractStateResponse, error)
+	SmartContractState(ctx context.Context, contractAddress string, queryData []byte) (*wasmtypes.QuerySmartContractStateResponse, error)
+	FetchCode(ctx context.Context, codeID uint64) (*wasmtypes.QueryCodeResponse, error)
+	FetchCodes(ctx context.Context, pagination *query.PageRequest) (*wasmtypes.QueryCodesResponse, error)
+	FetchPinnedCodes(ctx context.Context, pagination *query.PageRequest) (*wasmtypes.QueryPinnedCodesResponse, error)
+}
+
+var _ ClientAPI = (*Client)(nil)
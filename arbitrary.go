@@ -0,0 +1,110 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+)
+
+// adr036MsgType is the amino type of the ADR-036 message
+const adr036MsgType = "sign/MsgSignData"
+
+type (
+	// adr036SignDoc is the amino JSON sign doc of ADR-036, fields are sorted as in the canonical JSON
+	adr036SignDoc struct {
+		AccountNumber string      `json:"account_number"`
+		ChainID       string      `json:"chain_id"`
+		Fee           adr036Fee   `json:"fee"`
+		Memo          string      `json:"memo"`
+		Msgs          []adr036Msg `json:"msgs"`
+		Sequence      string      `json:"sequence"`
+	}
+	adr036Fee struct {
+		Amount []struct{} `json:"amount"`
+		Gas    string     `json:"gas"`
+	}
+	adr036Msg struct {
+		Type  string         `json:"type"`
+		Value adr036MsgValue `json:"value"`
+	}
+	adr036MsgValue struct {
+		Data   []byte `json:"data"`
+		Signer string `json:"signer"`
+	}
+)
+
+// adr036SignBytes returns ADR-036 sign bytes of the data signed by the address, as produced by Keplr signArbitrary
+func adr036SignBytes(signer string, data []byte) ([]byte, error) {
+	bz, err := json.Marshal(adr036SignDoc{
+		AccountNumber: "0",
+		Fee:           adr036Fee{Amount: []struct{}{}, Gas: "0"},
+		Msgs:          []adr036Msg{{Type: adr036MsgType, Value: adr036MsgValue{Data: data, Signer: signer}}},
+		Sequence:      "0",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return sdktypes.SortJSON(bz)
+}
+
+// SignArbitrary signs arbitrary data with the signer as ADR-036 off-chain message, e.g. for wallet based login.
+// The signature is verifiable by VerifyArbitrary, Keplr-style wallets and contracts
+func (c *Client) SignArbitrary(ctx context.Context, signerName string, data []byte) (legacytx.StdSignature, error) {
+	sgn, release, err := c.acquireSigner(signerName)
+	if err != nil {
+		return legacytx.StdSignature{}, err
+	}
+	defer release()
+
+	signer, err := bech32.ConvertAndEncode(accountPrefix(), sgn.key.Address())
+	if err != nil {
+		return legacytx.StdSignature{}, fmt.Errorf("ConvertAndEncode: %w", err)
+	}
+
+	signBytes, err := adr036SignBytes(signer, data)
+	if err != nil {
+		return legacytx.StdSignature{}, err
+	}
+
+	sig, err := sgn.key.Sign(ctx, signBytes)
+	if err != nil {
+		return legacytx.StdSignature{}, fmt.Errorf("Sign: %w", err)
+	}
+
+	return legacytx.StdSignature{PubKey: sgn.key.PubKey(), Signature: sig}, nil
+}
+
+// VerifyArbitrary verifies ADR-036 signature of arbitrary data made by the account address. It checks that
+// the signature public key belongs to the address and that the signature is valid
+func VerifyArbitrary(address string, data []byte, sig legacytx.StdSignature) error {
+	hrp, addrBytes, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return fmt.Errorf("DecodeAndConvert: %w", err)
+	}
+	if prefix := accountPrefix(); hrp != prefix {
+		return fmt.Errorf("invalid address prefix %s, expected %s", hrp, prefix)
+	}
+	if sig.PubKey == nil {
+		return errors.New("empty public key")
+	}
+	if !bytes.Equal(sig.PubKey.Address(), addrBytes) {
+		return fmt.Errorf("public key does not belong to %s", address)
+	}
+
+	signBytes, err := adr036SignBytes(address, data)
+	if err != nil {
+		return err
+	}
+	if !sig.PubKey.VerifySignature(signBytes, sig.Signature) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"gotest.tools/assert"
+)
+
+func TestADR036SignBytes(t *testing.T) {
+	bz, err := adr036SignBytes("sei1signer", []byte("hello"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(bz), `{"account_number":"0","chain_id":"","fee":{"amount":[],"gas":"0"},"memo":"",`+
+		`"msgs":[{"type":"sign/MsgSignData","value":{"data":"aGVsbG8=","signer":"sei1signer"}}],"sequence":"0"}`)
+}
+
+func TestClient_SignArbitrary(t *testing.T) {
+	c := newKeyringClient()
+	ctx := context.Background()
+
+	_, err := c.AddSigner("signer", testMnemonic1)
+	assert.NilError(t, err)
+	sgn, err := c.getSigner("signer")
+	assert.NilError(t, err)
+	_, err = c.AddSigner("other", testMnemonic2)
+	assert.NilError(t, err)
+	other, err := c.getSigner("other")
+	assert.NilError(t, err)
+
+	address, err := bech32.ConvertAndEncode(Bech32PrefixAccAddr, sgn.key.Address())
+	assert.NilError(t, err)
+	otherAddress, err := bech32.ConvertAndEncode(Bech32PrefixAccAddr, other.key.Address())
+	assert.NilError(t, err)
+
+	sig, err := c.SignArbitrary(ctx, "signer", []byte("login nonce 42"))
+	assert.NilError(t, err)
+	assert.NilError(t, VerifyArbitrary(address, []byte("login nonce 42"), sig))
+
+	assert.ErrorContains(t, VerifyArbitrary(address, []byte("login nonce 43"), sig), "invalid signature")
+	assert.ErrorContains(t, VerifyArbitrary(otherAddress, []byte("login nonce 42"), sig), "does not belong")
+
+	cosmosAddress, err := bech32.ConvertAndEncode("cosmos", sgn.key.Address())
+	assert.NilError(t, err)
+	assert.ErrorContains(t, VerifyArbitrary(cosmosAddress, []byte("login nonce 42"), sig), "invalid address prefix")
+
+	_, err = c.SignArbitrary(ctx, "missing", []byte("data"))
+	assert.ErrorContains(t, err, "not added")
+}
@@ -0,0 +1,136 @@
+package sdk
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// AuthConfig configures authentication of gRPC and RPC requests and TLS of the connections.
+// Note that RPC websocket subscriptions are sent without the auth headers
+type AuthConfig struct {
+	// Username and Password are sent as basic auth
+	Username string
+	Password string
+	// BearerToken is sent as bearer auth, it can't be combined with basic auth
+	BearerToken string
+	// Headers are sent with every request, e.g. {"x-api-key": "..."}. gRPC metadata keys are lower case
+	Headers map[string]string
+
+	// CAFile is PEM bundle of CAs verifying node certificates, system CAs are used by default
+	CAFile string
+	// CertFile and KeyFile are PEM client certificate and key for mTLS
+	CertFile string
+	KeyFile  string
+}
+
+func (a *AuthConfig) validate() error {
+	if a == nil {
+		return nil
+	}
+	if a.BearerToken != "" && (a.Username != "" || a.Password != "") {
+		return errors.New("BearerToken can't be combined with basic auth")
+	}
+	if (a.CertFile == "") != (a.KeyFile == "") {
+		return errors.New("CertFile and KeyFile must be set together")
+	}
+	for k := range a.Headers {
+		if strings.EqualFold(k, "authorization") && (a.BearerToken != "" || a.Username != "" || a.Password != "") {
+			return errors.New("authorization header can't be combined with basic or bearer auth")
+		}
+	}
+
+	return nil
+}
+
+// usesTLS reports whether TLS settings are set
+func (a *AuthConfig) usesTLS() bool {
+	return a != nil && (a.CAFile != "" || a.CertFile != "")
+}
+
+// headers returns headers sent with every request
+func (a *AuthConfig) headers() map[string]string {
+	if a == nil {
+		return nil
+	}
+
+	headers := make(map[string]string, len(a.Headers)+1)
+	for k, v := range a.Headers {
+		headers[k] = v
+	}
+	switch {
+	case a.BearerToken != "":
+		headers["Authorization"] = "Bearer " + a.BearerToken
+	case a.Username != "" || a.Password != "":
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(a.Username+":"+a.Password))
+	}
+
+	return headers
+}
+
+// tlsConfig returns TLS config with the CA bundle and the client certificate, nil when neither is set
+func (a *AuthConfig) tlsConfig() (*tls.Config, error) {
+	if !a.usesTLS() {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if a.CAFile != "" {
+		pem, err := os.ReadFile(a.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", a.CAFile)
+		}
+	}
+	if a.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(a.CertFile, a.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls.LoadX509KeyPair: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+// headerCredentials is credentials.PerRPCCredentials sending the auth headers as gRPC metadata
+type headerCredentials struct {
+	headers map[string]string
+}
+
+func (h headerCredentials) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	md := make(map[string]string, len(h.headers))
+	for k, v := range h.headers {
+		md[strings.ToLower(k)] = v
+	}
+
+	return md, nil
+}
+
+func (headerCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+// headerTransport adds the auth headers to RPC requests
+type headerTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+}
+
+func (t headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for k, v := range t.headers {
+		req.Header.Set(k, v)
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,230 @@
+package sdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+// newHealthRPC starts RPC server answering health requests and recording request headers
+func newHealthRPC(t *testing.T, tlsConfig *tls.Config) (*httptest.Server, func() http.Header) {
+	var (
+		mu     sync.Mutex
+		header http.Header
+	)
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		header = r.Header.Clone()
+		mu.Unlock()
+
+		var req struct {
+			ID json.RawMessage `json:"id"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		_ = json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": map[string]any{}})
+	}))
+	// rejected handshakes are expected
+	srv.Config.ErrorLog = log.New(io.Discard, "", 0)
+	if tlsConfig != nil {
+		srv.TLS = tlsConfig
+		srv.StartTLS()
+	} else {
+		srv.Start()
+	}
+	t.Cleanup(srv.Close)
+
+	return srv, func() http.Header {
+		mu.Lock()
+		defer mu.Unlock()
+
+		return header
+	}
+}
+
+// writeClientCert writes self-signed client certificate and its key as PEM files
+func writeClientCert(t *testing.T, dir string) (certFile, keyFile string, cert *x509.Certificate) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NilError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.NilError(t, err)
+	cert, err = x509.ParseCertificate(der)
+	assert.NilError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NilError(t, err)
+
+	certFile, keyFile = filepath.Join(dir, "client.pem"), filepath.Join(dir, "client-key.pem")
+	assert.NilError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	assert.NilError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile, cert
+}
+
+func TestAuthConfig_Headers(t *testing.T) {
+	var auth *AuthConfig
+	assert.Equal(t, len(auth.headers()), 0)
+
+	auth = &AuthConfig{BearerToken: "token", Headers: map[string]string{"X-Api-Key": "key"}}
+	assert.DeepEqual(t, auth.headers(), map[string]string{"Authorization": "Bearer token", "X-Api-Key": "key"})
+
+	auth = &AuthConfig{Username: "user", Password: "pass"}
+	assert.DeepEqual(t, auth.headers(), map[string]string{"Authorization": "Basic dXNlcjpwYXNz"})
+
+	md, err := headerCredentials{headers: auth.headers()}.GetRequestMetadata(context.Background())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, md, map[string]string{"authorization": "Basic dXNlcjpwYXNz"})
+}
+
+func TestGetRPCClient_Auth(t *testing.T) {
+	srv, header := newHealthRPC(t, nil)
+
+	rpc, err := getRPCClient(srv.URL, &AuthConfig{BearerToken: "token", Headers: map[string]string{"x-api-key": "key"}})
+	assert.NilError(t, err)
+	_, err = rpc.Health(context.Background())
+	assert.NilError(t, err)
+
+	assert.Equal(t, header().Get("Authorization"), "Bearer token")
+	assert.Equal(t, header().Get("X-Api-Key"), "key")
+}
+
+func TestGetRPCClient_MutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, clientCert := writeClientCert(t, dir)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(clientCert)
+	srv, _ := newHealthRPC(t, &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs, MinVersion: tls.VersionTLS12})
+
+	caFile := filepath.Join(dir, "ca.pem")
+	assert.NilError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0o600))
+
+	// the test server certificate is not trusted by the system
+	rpc, err := getRPCClient(srv.URL, nil)
+	assert.NilError(t, err)
+	_, err = rpc.Health(context.Background())
+	assert.ErrorContains(t, err, "certificate")
+
+	// the server requires client certificate
+	rpc, err = getRPCClient(srv.URL, &AuthConfig{CAFile: caFile})
+	assert.NilError(t, err)
+	_, err = rpc.Health(context.Background())
+	assert.Assert(t, err != nil)
+
+	rpc, err = getRPCClient(srv.URL, &AuthConfig{CAFile: caFile, CertFile: certFile, KeyFile: keyFile})
+	assert.NilError(t, err)
+	_, err = rpc.Health(context.Background())
+	assert.NilError(t, err)
+
+	_, err = getRPCClient(srv.URL, &AuthConfig{CAFile: certFile + ".missing"})
+	assert.ErrorContains(t, err, "tlsConfig")
+}
+
+func TestGetGRPCConn_Auth(t *testing.T) {
+	mdCh := make(chan metadata.MD, 1)
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(func(_ any, stream grpc.ServerStream) error {
+		md, _ := metadata.FromIncomingContext(stream.Context())
+		mdCh <- md
+		return status.Error(codes.Unimplemented, "unknown service")
+	}))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	cfg := Config{InsecureGRPC: true, Auth: AuthConfig{BearerToken: "token", Headers: map[string]string{"X-Api-Key": "key"}}}
+	conn, err := getGRPCConn(cfg, Endpoint{GRPCHost: lis.Addr().String()})
+	assert.NilError(t, err)
+	defer conn.Close()
+
+	_, err = txtypes.NewServiceClient(conn).GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.Equal(t, status.Code(err), codes.Unimplemented)
+
+	md := <-mdCh
+	assert.DeepEqual(t, md.Get("authorization"), []string{"Bearer token"})
+	assert.DeepEqual(t, md.Get("x-api-key"), []string{"key"})
+
+	// endpoint auth overrides the config auth
+	conn, err = getGRPCConn(cfg, Endpoint{GRPCHost: lis.Addr().String(), Auth: &AuthConfig{Username: "user", Password: "pass"}})
+	assert.NilError(t, err)
+	defer conn.Close()
+
+	_, err = txtypes.NewServiceClient(conn).GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.Equal(t, status.Code(err), codes.Unimplemented)
+
+	md = <-mdCh
+	assert.DeepEqual(t, md.Get("authorization"), []string{"Basic dXNlcjpwYXNz"})
+	assert.Equal(t, len(md.Get("x-api-key")), 0)
+}
+
+func TestConfig_ValidateAuth(t *testing.T) {
+	cfg := Config{ChainID: testChainID, GRPCHost: "localhost:9090", RPCHost: "http://localhost:26657", Auth: AuthConfig{BearerToken: "token"}}
+	assert.NilError(t, cfg.Validate())
+
+	cfg.Auth.Username = "user"
+	assert.ErrorContains(t, cfg.Validate(), "Auth: BearerToken can't be combined with basic auth")
+
+	cfg.Auth = AuthConfig{CertFile: "client.pem"}
+	assert.ErrorContains(t, cfg.Validate(), "Auth: CertFile and KeyFile must be set together")
+
+	cfg.Auth = AuthConfig{BearerToken: "token", Headers: map[string]string{"Authorization": "Token x"}}
+	assert.ErrorContains(t, cfg.Validate(), "authorization header can't be combined")
+
+	cfg.Auth = AuthConfig{Headers: map[string]string{"x-api-key": "key"}}
+	cfg.UseBasicAuth = true
+	assert.ErrorContains(t, cfg.Validate(), "UseBasicAuth can't be combined with Auth")
+
+	cfg.Auth, cfg.UseBasicAuth = AuthConfig{CAFile: "ca.pem"}, false
+	cfg.InsecureGRPC = true
+	assert.ErrorContains(t, cfg.Validate(), "InsecureGRPC can't be combined with Auth TLS settings")
+
+	cfg.Auth = AuthConfig{BearerToken: "token"}
+	assert.NilError(t, cfg.Validate())
+
+	cfg.Auth = AuthConfig{}
+	cfg.Endpoints = []Endpoint{{GRPCHost: "localhost:9091", RPCHost: "http://localhost:26658", Auth: &AuthConfig{CertFile: "client.pem", KeyFile: "key.pem"}}}
+	assert.ErrorContains(t, cfg.Validate(), "endpoint 0: InsecureGRPC can't be combined with Auth TLS settings")
+
+	cfg.InsecureGRPC = false
+	assert.NilError(t, cfg.Validate())
+
+	cfg.Endpoints = []Endpoint{{GRPCHost: "localhost:9091", RPCHost: "http://localhost:26658", Auth: &AuthConfig{KeyFile: "key.pem"}}}
+	assert.ErrorContains(t, cfg.Validate(), "endpoint 0: Auth: CertFile and KeyFile")
+
+	cfg.Endpoints = []Endpoint{{GRPCHost: "localhost:9091", RPCHost: "http://localhost:26658", Auth: &AuthConfig{BearerToken: "token"}}}
+	cfg.UseBasicAuth = true
+	assert.ErrorContains(t, cfg.Validate(), "endpoint 0: UseBasicAuth can't be combined with Auth")
+
+	cfg.Endpoints[0].Auth = &AuthConfig{}
+	assert.NilError(t, cfg.Validate())
+}
@@ -0,0 +1,103 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	cosmosTypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	// bech32ConfigMu serializes configuration of the global SDK config
+	bech32ConfigMu sync.Mutex
+	// bech32Configured is set once a client configured the global SDK config
+	bech32Configured bool
+)
+
+// Bech32Prefixes are bech32 prefixes of addresses. Empty prefixes are derived from Account as the SDK does,
+// public key prefixes are the address prefixes with "pub" suffix
+type Bech32Prefixes struct {
+	// Account address prefix, Bech32PrefixAccAddr by default
+	Account string
+	// Validator operator address prefix, Account + "valoper" by default
+	Validator string
+	// Consensus node address prefix, Account + "valcons" by default
+	Consensus string
+}
+
+func (p Bech32Prefixes) withDefaults() Bech32Prefixes {
+	if p.Account == "" {
+		p.Account = Bech32PrefixAccAddr
+	}
+	if p.Validator == "" {
+		p.Validator = p.Account + "valoper"
+	}
+	if p.Consensus == "" {
+		p.Consensus = p.Account + "valcons"
+	}
+
+	return p
+}
+
+// validate checks the prefixes with the defaults filled in, so derived prefixes can't collide with the set ones
+func (p Bech32Prefixes) validate() error {
+	p = p.withDefaults()
+	for _, prefix := range []string{p.Account, p.Validator, p.Consensus} {
+		for _, r := range prefix {
+			// bech32 human-readable part is lower case ASCII
+			if r < 33 || r > 126 || (r >= 'A' && r <= 'Z') {
+				return fmt.Errorf("invalid bech32 prefix %q", prefix)
+			}
+		}
+	}
+	if p.Validator == p.Account || p.Consensus == p.Account || p.Consensus == p.Validator {
+		return errors.New("bech32 prefixes are not unique")
+	}
+
+	return nil
+}
+
+// configureBech32 sets bech32 prefixes of the global SDK config, which is used by the SDK to encode addresses,
+// and seals it. The config is process wide, so it is set once and later calls only check the prefixes match.
+// It fails when the config is already sealed with other prefixes, e.g. by another client or the application
+func configureBech32(prefixes Bech32Prefixes) (err error) {
+	bech32ConfigMu.Lock()
+	defer bech32ConfigMu.Unlock()
+
+	p := prefixes.withDefaults()
+	config := cosmosTypes.GetConfig()
+	if config.GetBech32AccountAddrPrefix() == p.Account && config.GetBech32AccountPubPrefix() == p.Account+"pub" &&
+		config.GetBech32ValidatorAddrPrefix() == p.Validator && config.GetBech32ValidatorPubPrefix() == p.Validator+"pub" &&
+		config.GetBech32ConsensusAddrPrefix() == p.Consensus && config.GetBech32ConsensusPubPrefix() == p.Consensus+"pub" {
+		config.Seal()
+		bech32Configured = true
+		return nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("global SDK config is sealed with bech32 prefix %s, can't use %s", config.GetBech32AccountAddrPrefix(), p.Account)
+		}
+	}()
+
+	config.SetBech32PrefixForAccount(p.Account, p.Account+"pub")
+	config.SetBech32PrefixForValidator(p.Validator, p.Validator+"pub")
+	config.SetBech32PrefixForConsensusNode(p.Consensus, p.Consensus+"pub")
+	config.Seal()
+	bech32Configured = true
+
+	return nil
+}
+
+// accountPrefix returns account address prefix of the clients of the process, Bech32PrefixAccAddr until a client is created
+func accountPrefix() string {
+	bech32ConfigMu.Lock()
+	defer bech32ConfigMu.Unlock()
+
+	if !bech32Configured {
+		return Bech32PrefixAccAddr
+	}
+
+	return cosmosTypes.GetConfig().GetBech32AccountAddrPrefix()
+}
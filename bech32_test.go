@@ -0,0 +1,110 @@
+package sdk
+
+import (
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"gotest.tools/assert"
+)
+
+// TestMain configures the sei prefixes before any address is encoded, as the SDK caches encoded addresses
+func TestMain(m *testing.M) {
+	err := configureBech32(Bech32Prefixes{})
+	if err != nil {
+		panic(err)
+	}
+
+	os.Exit(m.Run())
+}
+
+func TestNewClient_Multiple(t *testing.T) {
+	configs := []Config{
+		{ChainID: ChainIDTestnet, GRPCHost: "localhost:9090", RPCHost: "http://localhost:26657", SkipChainIDCheck: true},
+		{ChainID: ChainIDMainnet, GRPCHost: "localhost:9091", RPCHost: "http://localhost:26658", SkipChainIDCheck: true},
+	}
+
+	clients := make([]*Client, len(configs)*2)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c, err := NewClient(configs[i%len(configs)])
+			assert.Check(t, err)
+			clients[i] = c
+		}()
+	}
+	wg.Wait()
+
+	for i, c := range clients {
+		assert.Assert(t, c != nil)
+		assert.Equal(t, c.clientCtx.ChainID, string(configs[i%len(configs)].ChainID))
+
+		// keys are not shared between clients
+		addr, err := c.AddSigner("signer", testMnemonic1)
+		assert.NilError(t, err)
+		assert.Assert(t, IsValidBlockchainAddress(addr), addr)
+	}
+}
+
+func TestConfigureBech32_Conflict(t *testing.T) {
+	assert.NilError(t, configureBech32(Bech32Prefixes{}))
+	assert.NilError(t, configureBech32(Bech32Prefixes{Account: "sei", Validator: "seivaloper", Consensus: "seivalcons"}))
+
+	err := configureBech32(Bech32Prefixes{Account: "cosmos"})
+	assert.ErrorContains(t, err, "sealed with bech32 prefix sei, can't use cosmos")
+
+	// all prefixes have to match
+	err = configureBech32(Bech32Prefixes{Validator: "seivalidator"})
+	assert.ErrorContains(t, err, "sealed with bech32 prefix sei")
+}
+
+func TestBech32Prefixes(t *testing.T) {
+	assert.Equal(t, Bech32Prefixes{}.withDefaults(), Bech32Prefixes{Account: "sei", Validator: "seivaloper", Consensus: "seivalcons"})
+	assert.Equal(t, Bech32Prefixes{Account: "fork"}.withDefaults(), Bech32Prefixes{Account: "fork", Validator: "forkvaloper", Consensus: "forkvalcons"})
+
+	assert.ErrorContains(t, Bech32Prefixes{Account: "Sei"}.validate(), `invalid bech32 prefix "Sei"`)
+	assert.ErrorContains(t, Bech32Prefixes{Account: "fork", Validator: "fork"}.validate(), "not unique")
+	// the default consensus prefix collides with the set validator prefix
+	assert.ErrorContains(t, Bech32Prefixes{Account: "fork", Validator: "forkvalcons"}.validate(), "not unique")
+	// the default account prefix collides with the set validator prefix
+	assert.ErrorContains(t, Bech32Prefixes{Validator: Bech32PrefixAccAddr}.validate(), "not unique")
+	assert.NilError(t, Bech32Prefixes{Account: "fork"}.validate())
+}
+
+func TestNewClient_ChainParams(t *testing.T) {
+	cfg := Config{ChainID: "my-fork-1", GRPCHost: "localhost:9090", RPCHost: "http://localhost:26657", GasPrice: "0.02usei", SkipChainIDCheck: true}
+	c, err := NewClient(cfg)
+	assert.NilError(t, err)
+	assert.Equal(t, c.clientCtx.ChainID, "my-fork-1")
+	assert.Equal(t, c.txFactory.GasPrices().String(), "0.020000000000000000usei")
+
+	// the prefixes of the process can't be changed
+	cfg.Bech32Prefixes = Bech32Prefixes{Account: "fork"}
+	_, err = NewClient(cfg)
+	assert.ErrorContains(t, err, "can't use fork")
+	forkAddr, err := bech32.ConvertAndEncode("fork", make([]byte, 20))
+	assert.NilError(t, err)
+	assert.Assert(t, !IsValidBlockchainAddress(forkAddr))
+}
+
+func TestConfig_ValidateChainParams(t *testing.T) {
+	cfg := Config{ChainID: testChainID, GRPCHost: "localhost:9090", RPCHost: "http://localhost:26657", Denom: "ufork"}
+	assert.NilError(t, cfg.Validate())
+	assert.Equal(t, cfg.gasPrice(), "0.1ufork")
+
+	cfg.GasPrice = "0.1usei"
+	assert.ErrorContains(t, cfg.Validate(), "GasPrice: denom usei differs from fee denom ufork")
+
+	cfg.GasPrice = "cheap"
+	assert.ErrorContains(t, cfg.Validate(), "GasPrice:")
+
+	cfg.GasPrice, cfg.Denom = "", "1"
+	assert.ErrorContains(t, cfg.Validate(), "Denom:")
+
+	cfg.Denom = ""
+	cfg.Bech32Prefixes = Bech32Prefixes{Account: "fork", Consensus: "fork"}
+	assert.ErrorContains(t, cfg.Validate(), "Bech32Prefixes: bech32 prefixes are not unique")
+}
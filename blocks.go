@@ -0,0 +1,194 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	defaultFollowPollInterval  = time.Second
+	defaultFollowMaxRetries    = 3
+	defaultFollowRetryInterval = time.Second
+)
+
+// BlockData is a block together with execution results of every tx in it
+type BlockData struct {
+	Height          int64
+	Hash            string
+	Time            time.Time
+	ProposerAddress string
+	// Events are begin and end block events, as reported in finalize_block_events
+	Events []abci.Event
+	// Txs are all txs of the block in execution order, including failed ones
+	Txs []BlockTx
+}
+
+// BlockTx is a tx of the block with its execution result
+type BlockTx struct {
+	Hash   string
+	Index  uint32
+	Tx     []byte
+	Result *abci.ExecTxResult
+}
+
+// FollowConfig configures block following
+type FollowConfig struct {
+	// StartHeight is the first delivered height. Blocks below latest height are backfilled. 0 means latest height
+	StartHeight int64
+	// EndHeight is the last delivered height, inclusive. 0 means follow new blocks until ctx is done.
+	// It requires StartHeight, as the latest height may already be above it
+	EndHeight int64
+
+	// PollInterval is the delay between latest height checks once the follower caught up
+	PollInterval time.Duration
+	// MaxRetries is the amount of refetches of a block before following is aborted
+	MaxRetries int
+	// RetryInterval is the delay between block refetches
+	RetryInterval time.Duration
+}
+
+// Validate validates follow config and fills defaults
+func (cfg *FollowConfig) Validate() error {
+	if cfg.StartHeight < 0 {
+		return errors.New("negative StartHeight")
+	}
+	if cfg.EndHeight != 0 && cfg.StartHeight == 0 {
+		return errors.New("EndHeight requires StartHeight")
+	}
+	if cfg.EndHeight != 0 && cfg.EndHeight < cfg.StartHeight {
+		return errors.New("EndHeight is lower than StartHeight")
+	}
+
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultFollowPollInterval
+	}
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultFollowMaxRetries
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = defaultFollowRetryInterval
+	}
+
+	return nil
+}
+
+// GetBlock retrieves block and its results at the given height
+func (c *Client) GetBlock(ctx context.Context, height int64) (_ *BlockData, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.GetBlock", attribute.Int64("sei.height", height))
+	defer func() { endSpan(span, err) }()
+
+	tendermintNode, err := c.clientCtx.GetNode()
+	if err != nil {
+		return nil, fmt.Errorf("clientCtx.GetNode: %w", err)
+	}
+
+	block, err := tendermintNode.Block(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("tendermintNode.Block: %w", err)
+	}
+	if block.Block == nil {
+		return nil, fmt.Errorf("empty block at height %d", height)
+	}
+
+	results, err := tendermintNode.BlockResults(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("tendermintNode.BlockResults: %w", err)
+	}
+	if len(results.TxsResults) != len(block.Block.Txs) {
+		return nil, fmt.Errorf("block %d has %d txs but %d results", height, len(block.Block.Txs), len(results.TxsResults))
+	}
+
+	data := &BlockData{
+		Height:          block.Block.Height,
+		Hash:            block.BlockID.Hash.String(),
+		Time:            block.Block.Time,
+		ProposerAddress: block.Block.ProposerAddress.String(),
+		Events:          results.FinalizeBlockEvents,
+		Txs:             make([]BlockTx, 0, len(block.Block.Txs)),
+	}
+	for i, tx := range block.Block.Txs {
+		data.Txs = append(data.Txs, BlockTx{
+			Hash:   fmt.Sprintf("%X", tmtypes.Tx(tx).Hash()),
+			Index:  uint32(i),
+			Tx:     tx,
+			Result: results.TxsResults[i],
+		})
+	}
+
+	return data, nil
+}
+
+// FollowBlocks delivers blocks with their results to the callback in height order.
+// It backfills blocks from StartHeight up to the latest height and then polls for new blocks.
+func (c *Client) FollowBlocks(ctx context.Context, cfg FollowConfig, handle func(ctx context.Context, block *BlockData) error) error {
+	err := cfg.Validate()
+	if err != nil {
+		return err
+	}
+
+	latest, err := c.GetLatestHeight(ctx)
+	if err != nil {
+		return fmt.Errorf("GetLatestHeight: %w", err)
+	}
+
+	height := cfg.StartHeight
+	if height == 0 {
+		height = latest
+	}
+
+	for cfg.EndHeight == 0 || height <= cfg.EndHeight {
+		for height > latest {
+			select {
+			case <-time.After(cfg.PollInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			latest, err = c.GetLatestHeight(ctx)
+			if err != nil {
+				return fmt.Errorf("GetLatestHeight: %w", err)
+			}
+		}
+
+		block, err := c.getBlockWithRetries(ctx, height, cfg.MaxRetries, cfg.RetryInterval)
+		if err != nil {
+			return err
+		}
+
+		err = handle(ctx, block)
+		if err != nil {
+			return err
+		}
+
+		height++
+	}
+
+	return nil
+}
+
+func (c *Client) getBlockWithRetries(ctx context.Context, height int64, retries int, interval time.Duration) (block *BlockData, err error) {
+	for i := range retries + 1 {
+		if i > 0 {
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		block, err = c.GetBlock(ctx, height)
+		if err == nil {
+			return block, nil
+		}
+	}
+
+	return nil, fmt.Errorf("GetBlock %d: %w", height, err)
+}
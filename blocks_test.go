@@ -0,0 +1,94 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+	"gotest.tools/assert"
+)
+
+func TestClient_GetBlock(t *testing.T) {
+	stub := newStubRPC(t, 2, 2, 3)
+	for i, tx := range stub.txs {
+		tx.Tx = []byte(fmt.Sprintf("tx %d", i))
+	}
+	c := newStubClient(t, stub)
+
+	block, err := c.GetBlock(context.Background(), 2)
+	assert.NilError(t, err)
+	assert.Equal(t, block.Height, int64(2))
+	assert.Equal(t, block.Time, time.Unix(2, 0).UTC())
+	assert.Equal(t, len(block.Txs), 2)
+	assert.Equal(t, block.Txs[1].Index, uint32(1))
+	assert.Equal(t, block.Txs[1].Hash, fmt.Sprintf("%X", tmtypes.Tx("tx 1").Hash()))
+	assert.Equal(t, string(block.Txs[1].Result.Events[0].Attributes[0].Value), "1")
+}
+
+func TestClient_FollowBlocks_Backfill(t *testing.T) {
+	stub := newStubRPC(t, 2, 2, 3)
+	stub.height = 10
+	c := newStubClient(t, stub)
+
+	var heights []int64
+	err := c.FollowBlocks(context.Background(), FollowConfig{StartHeight: 1, EndHeight: 3}, func(_ context.Context, block *BlockData) error {
+		heights = append(heights, block.Height)
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, heights, []int64{1, 2, 3})
+}
+
+func TestClient_FollowBlocks_Polling(t *testing.T) {
+	stub := newStubRPC(t)
+	stub.height = 2
+	c := newStubClient(t, stub)
+
+	var heights []int64
+	err := c.FollowBlocks(context.Background(), FollowConfig{StartHeight: 1, EndHeight: 4, PollInterval: 10 * time.Millisecond}, func(_ context.Context, block *BlockData) error {
+		heights = append(heights, block.Height)
+		if block.Height == 2 {
+			// new blocks are committed after the follower caught up
+			go func() {
+				time.Sleep(30 * time.Millisecond)
+				stub.setHeight(4)
+			}()
+		}
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, heights, []int64{1, 2, 3, 4})
+}
+
+func TestClient_FollowBlocks_Latest(t *testing.T) {
+	stub := newStubRPC(t)
+	stub.height = 7
+	c := newStubClient(t, stub)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var heights []int64
+	err := c.FollowBlocks(ctx, FollowConfig{PollInterval: time.Millisecond}, func(_ context.Context, block *BlockData) error {
+		heights = append(heights, block.Height)
+		cancel()
+		return nil
+	})
+	assert.Assert(t, errors.Is(err, context.Canceled))
+	assert.DeepEqual(t, heights, []int64{7})
+}
+
+func TestFollowConfig_Validate(t *testing.T) {
+	cfg := FollowConfig{EndHeight: 3}
+	assert.ErrorContains(t, cfg.Validate(), "EndHeight requires StartHeight")
+
+	cfg = FollowConfig{StartHeight: 5, EndHeight: 3}
+	assert.ErrorContains(t, cfg.Validate(), "EndHeight is lower than StartHeight")
+
+	cfg = FollowConfig{StartHeight: 5}
+	assert.NilError(t, cfg.Validate())
+	assert.Equal(t, cfg.MaxRetries, defaultFollowMaxRetries)
+}
@@ -4,10 +4,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 
+	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/tx"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // broadcastTx signs and broadcasts tx to the network
@@ -15,45 +23,141 @@ import (
 // - retrieves the proper acc sequence via GetAccountNumberSequence
 // - runs the simulation via Simulate
 // - adjusts Gas
+//
+// The stages are traced as child spans of the tx span
 func (c *Client) broadcastTx(ctx context.Context, sgn signer, msgs ...sdktypes.Msg) (resp *txtypes.BroadcastTxResponse, err error) {
-	if !c.canSign {
+	ctx, span := c.telemetry.start(ctx, "sei.broadcastTx", txAttributes(sgn.address.String(), msgs)...)
+	// message summaries are built only when they are logged
+	if c.log().Enabled(ctx, slog.LevelDebug) {
+		c.log().DebugContext(ctx, "broadcasting tx", "signer", sgn.name, "address", sgn.address.String(), msgLogAttrs(msgs))
+	}
+	defer func() {
+		var txHash string
+		if resp.GetTxResponse() != nil {
+			txHash = resp.GetTxResponse().TxHash
+			span.SetAttributes(attribute.String("sei.tx_hash", txHash))
+		}
+		endSpan(span, err)
+		if err != nil {
+			c.log().WarnContext(ctx, "tx failed", "signer", sgn.name, "address", sgn.address.String(), "tx_hash", txHash, "error", err)
+		}
+	}()
+
+	if c.signers.len() == 0 {
 		return resp, errors.New("can't sign. Add signature before sending tx")
 	}
 	if sgn.address.Empty() {
 		return resp, errors.New("empty signer")
 	}
 
-	num, seq, err := c.clientCtx.AccountRetriever.GetAccountNumberSequence(c.clientCtx, sgn.address.Bytes())
+	txf, txn, err := c.buildTx(ctx, sgn.key.Address(), sgn.key.PubKey(), msgs...)
+	if err != nil {
+		return resp, err
+	}
+
+	txBytes, err := c.signTxBytes(ctx, txf, sgn.key, txn)
+	if err != nil {
+		return resp, err
+	}
+
+	resp, err = c.broadcastTxBytes(ctx, txBytes)
+	c.telemetry.observeBroadcast(txn.GetTx().GetFee(), err)
+
+	return resp, err
+}
+
+// signTxBytes signs tx with the signer and encodes it
+func (c *Client) signTxBytes(ctx context.Context, txf tx.Factory, key Signer, txn client.TxBuilder) (txBytes []byte, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.sign")
+	defer func() { endSpan(span, err) }()
+
+	err = c.signTx(ctx, txf, key, txn)
+	if err != nil {
+		return nil, fmt.Errorf("Sign: %s", err)
+	}
+
+	txBytes, err = c.clientCtx.TxConfig.TxEncoder()(txn.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("TxEncoder: %s", err)
+	}
+
+	return txBytes, nil
+}
+
+// buildTx retrieves account number and sequence of the address and builds unsigned tx with gas adjusted
+// by the simulation. pubKey is used for the simulation signature
+func (c *Client) buildTx(ctx context.Context, address sdktypes.AccAddress, pubKey cryptotypes.PubKey, msgs ...sdktypes.Msg) (tx.Factory, client.TxBuilder, error) {
+	num, seq, err := c.accountNumberSequence(ctx, address)
 	if err != nil {
-		return resp, fmt.Errorf("GetAccountNumberSequence: %s", err)
+		return tx.Factory{}, nil, fmt.Errorf("GetAccountNumberSequence: %s", err)
 	}
-	txf := c.txFactory.WithSequence(seq).WithAccountNumber(num)
+	txf := c.factory(ctx).WithSequence(seq).WithAccountNumber(num)
 
-	simTxBytes, err := txf.BuildSimTx(msgs...)
+	simTxBytes, err := c.buildSimTx(txf, pubKey, msgs...)
 	if err != nil {
-		return resp, fmt.Errorf("BuildSimTx: %s", err)
+		return tx.Factory{}, nil, fmt.Errorf("BuildSimTx: %s", err)
 	}
-	simRes, err := c.txClient.Simulate(ctx, &txtypes.SimulateRequest{TxBytes: simTxBytes})
+	simRes, err := c.simulate(ctx, simTxBytes)
 	if err != nil {
-		return resp, fmt.Errorf("Simulate: %s", err)
+		return tx.Factory{}, nil, fmt.Errorf("Simulate: %s", err)
 	}
 
 	adjustedGas := uint64(txf.GasAdjustment() * float64(simRes.GasInfo.GetGasUsed()))
 	txf = txf.WithGas(adjustedGas)
+	c.log().DebugContext(ctx, "tx simulated", "address", address.String(), "account_number", num, "sequence", seq,
+		"gas_used", simRes.GasInfo.GetGasUsed(), "gas_wanted", adjustedGas)
 	txn, err := txf.BuildUnsignedTx(msgs...)
 	if err != nil {
-		return resp, fmt.Errorf("BuildUnsignedTx: %s", err)
+		return tx.Factory{}, nil, fmt.Errorf("BuildUnsignedTx: %s", err)
+	}
+
+	return txf, txn, nil
+}
+
+// accountNumberSequence retrieves account number and sequence of the address. The account is queried with the context
+// of the span, AccountRetriever of the client context drops it
+func (c *Client) accountNumberSequence(ctx context.Context, address sdktypes.AccAddress) (num, seq uint64, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.accountSequence")
+	defer func() { endSpan(span, err) }()
+
+	res, err := c.authQueryClient.Account(ctx, &authtypes.QueryAccountRequest{Address: address.String()})
+	if err != nil {
+		return 0, 0, err
 	}
 
-	err = tx.Sign(txf, sgn.name, txn, true)
+	var acc authtypes.AccountI
+	err = c.clientCtx.InterfaceRegistry.UnpackAny(res.Account, &acc)
 	if err != nil {
-		return resp, fmt.Errorf("Sign: %s", err)
+		return 0, 0, fmt.Errorf("UnpackAny: %w", err)
 	}
 
-	txBytes, err := c.clientCtx.TxConfig.TxEncoder()(txn.GetTx())
+	return acc.GetAccountNumber(), acc.GetSequence(), nil
+}
+
+// simulate simulates the tx, recording the gas used
+func (c *Client) simulate(ctx context.Context, txBytes []byte) (res *txtypes.SimulateResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.simulate")
+	defer func() { endSpan(span, err) }()
+
+	res, err = c.txClient.Simulate(ctx, &txtypes.SimulateRequest{TxBytes: txBytes})
 	if err != nil {
-		return resp, fmt.Errorf("TxEncoder: %s", err)
+		return nil, err
 	}
+	span.SetAttributes(attribute.Int64("sei.gas_used", int64(res.GasInfo.GetGasUsed())))
+	c.telemetry.observeGas(res.GasInfo.GetGasUsed())
+
+	return res, nil
+}
+
+// broadcastTxBytes broadcasts signed protobuf encoded tx in sync mode
+func (c *Client) broadcastTxBytes(ctx context.Context, txBytes []byte) (resp *txtypes.BroadcastTxResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.broadcast")
+	defer func() {
+		if resp.GetTxResponse() != nil {
+			span.SetAttributes(attribute.String("sei.tx_hash", resp.GetTxResponse().TxHash))
+		}
+		endSpan(span, err)
+	}()
 
 	resp, err = c.txClient.BroadcastTx(ctx, &txtypes.BroadcastTxRequest{
 		TxBytes: txBytes,
@@ -71,6 +175,76 @@ func (c *Client) broadcastTx(ctx context.Context, sgn signer, msgs ...sdktypes.M
 	if resp.GetTxResponse().TxHash == "" {
 		return resp, errors.New("empty TxHash")
 	}
+	c.log().DebugContext(ctx, "tx broadcast", "tx_hash", resp.GetTxResponse().TxHash, "code", resp.GetTxResponse().Code)
 
 	return
 }
+
+// buildSimTx builds tx for simulation with an empty signature of the signer public key
+func (c *Client) buildSimTx(txf tx.Factory, pubKey cryptotypes.PubKey, msgs ...sdktypes.Msg) ([]byte, error) {
+	txn, err := txf.BuildUnsignedTx(msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	var sigData signing.SignatureData = &signing.SingleSignatureData{SignMode: c.signMode(txf)}
+	if multisigKey, ok := pubKey.(*kmultisig.LegacyAminoPubKey); ok {
+		sigData = multisigSimSignature(multisigKey)
+	}
+
+	err = txn.SetSignatures(signing.SignatureV2{
+		PubKey:   pubKey,
+		Data:     sigData,
+		Sequence: txf.Sequence(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.clientCtx.TxConfig.TxEncoder()(txn.GetTx())
+}
+
+// signTx signs tx with the signer, replacing existing signatures. It follows tx.Sign of the SDK,
+// but the sign bytes are passed to the Signer instead of the keyring
+func (c *Client) signTx(ctx context.Context, txf tx.Factory, key Signer, txn client.TxBuilder) error {
+	signMode := c.signMode(txf)
+	signerData := authsigning.SignerData{
+		ChainID:       txf.ChainID(),
+		AccountNumber: txf.AccountNumber(),
+		Sequence:      txf.Sequence(),
+	}
+
+	// SIGN_MODE_DIRECT sign bytes include signer infos, which are set together with the empty signature
+	sig := signing.SignatureV2{
+		PubKey:   key.PubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: signMode},
+		Sequence: txf.Sequence(),
+	}
+	err := txn.SetSignatures(sig)
+	if err != nil {
+		return err
+	}
+
+	signBytes, err := c.clientCtx.TxConfig.SignModeHandler().GetSignBytes(signMode, signerData, txn.GetTx())
+	if err != nil {
+		return fmt.Errorf("GetSignBytes: %w", err)
+	}
+
+	sigBytes, err := key.Sign(ctx, signBytes)
+	if err != nil {
+		return err
+	}
+
+	sig.Data = &signing.SingleSignatureData{SignMode: signMode, Signature: sigBytes}
+
+	return txn.SetSignatures(sig)
+}
+
+// signMode returns sign mode of the factory or the default mode of the tx config
+func (c *Client) signMode(txf tx.Factory) signing.SignMode {
+	if mode := txf.SignMode(); mode != signing.SignMode_SIGN_MODE_UNSPECIFIED {
+		return mode
+	}
+
+	return c.clientCtx.TxConfig.SignModeHandler().DefaultMode()
+}
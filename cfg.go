@@ -2,34 +2,223 @@ package sdk
 
 import (
 	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	cosmosTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 )
 
 const (
-	ChainIDTestnet chainID = "atlantic-2"
-	ChainIDMainnet chainID = "pacific-1"
+	ChainIDTestnet ChainID = "atlantic-2"
+	ChainIDMainnet ChainID = "pacific-1"
+	ChainIDDevnet  ChainID = "arctic-1"
+	// ChainIDLocal is the chain ID of a local node started by sei-chain scripts
+	ChainIDLocal ChainID = "sei-chain"
 )
 
 type (
-	chainID string
+	// ChainID identifies the network, any chain ID of a Sei fork or private network can be used
+	ChainID string
 	Config  struct {
 		GRPCHost string
 		RPCHost  string
 
-		ChainID chainID
+		// ChainID txs are signed for and the nodes must be on, required unless SkipChainIDCheck is set
+		ChainID ChainID
+		// Bech32Prefixes of addresses, sei prefixes by default. Unlike other settings they are not per client: addresses
+		// are encoded with the process wide Cosmos SDK config, which the first client sets and seals, so all clients
+		// of a process must use the same prefixes and NewClient fails otherwise
+		Bech32Prefixes Bech32Prefixes
+		// Denom fees are paid with, DefaultDenom by default
+		Denom string
 
+		// InsecureGRPC connects to gRPC without TLS, it can't be combined with CAFile and CertFile of Auth
 		InsecureGRPC bool
+		// UseBasicAuth is the legacy auth mode: GRPCHost is an URL https://<username>.host/<password>,
+		// the gRPC port is 9090. Use Auth instead
 		UseBasicAuth bool
+		// Auth configures authentication and TLS of the endpoints
+		Auth AuthConfig
+
+		// GasPrice is the minimum gas price with Denom, e.g. 0.1usei, DefaultGasPrice of Denom by default
+		GasPrice string
+
+		// Keyring selects where signer keys are stored, in memory by default
+		Keyring KeyringConfig
+
+		// SignMode of txs, SIGN_MODE_DIRECT by default. It can be overridden per tx with WithSignMode
+		SignMode signing.SignMode
+
+		// Endpoints are additional nodes requests fail over to, GRPCHost and RPCHost are the first endpoint if set
+		Endpoints []Endpoint
+		// Balancing selects endpoint for queries, BalanceRoundRobin by default
+		Balancing BalancingStrategy
+		// HealthCheck configures health checks of the endpoints, they are checked only when there are several endpoints
+		HealthCheck HealthCheckConfig
+		// SkipChainIDCheck disables the check that the nodes are on the ChainID network, done by NewClient and health checks.
+		// It allows creating client without reachable nodes, e.g. for offline signing
+		SkipChainIDCheck bool
+
+		// Retry configures retries of queries failed with transient errors
+		Retry RetryPolicy
+		// RateLimit limits requests sent to every endpoint, not limited by default
+		RateLimit RateLimit
+		// CallTimeout is the deadline of a query including retries, applied when it is earlier than the context deadline. Not limited by default
+		CallTimeout time.Duration
+
+		// Telemetry configures OpenTelemetry spans and Prometheus metrics
+		Telemetry TelemetryConfig
+
+		// Logger logs connection setup, failovers, txs and tx lookups, nothing is logged by default.
+		// Request and response summaries are logged at debug level
+		Logger *slog.Logger
+		// LogRedactFields are log attributes and fields of contract messages, which are logged as [REDACTED].
+		// Mnemonics, keys, passphrases and signatures are always redacted
+		LogRedactFields []string
+	}
+
+	// KeyringConfig configures keyring backend. Keys of file and test backends are loaded as signers on start
+	KeyringConfig struct {
+		// Backend is one of KeyringBackendMemory, KeyringBackendFile and KeyringBackendTest
+		Backend string
+		// Dir is the keyring root dir, keys are kept in its keyring-<backend> subdir as seid does
+		Dir string
+		// Passphrase encrypts the file backend, it is never asked for interactively
+		Passphrase string
 	}
 )
 
 // Validate validates config for empty fields
 func (cfg *Config) Validate() error {
-	if cfg.RPCHost == "" {
-		return errors.New("empty RPCHost")
+	// an empty chain ID matches no node, a tx signed for it is rejected by any network
+	if cfg.ChainID == "" && !cfg.SkipChainIDCheck {
+		return errors.New("empty ChainID")
 	}
-	if cfg.GRPCHost == "" {
-		return errors.New("empty GRPCHost")
+	if len(cfg.Endpoints) == 0 {
+		if cfg.RPCHost == "" {
+			return errors.New("empty RPCHost")
+		}
+		if cfg.GRPCHost == "" {
+			return errors.New("empty GRPCHost")
+		}
+	}
+	for i, e := range cfg.endpoints() {
+		if e.RPCHost == "" {
+			return fmt.Errorf("endpoint %d: empty RPCHost", i)
+		}
+		if e.GRPCHost == "" {
+			return fmt.Errorf("endpoint %d: empty GRPCHost", i)
+		}
+	}
+
+	err := cfg.Auth.validate()
+	if err != nil {
+		return fmt.Errorf("Auth: %w", err)
+	}
+	for i, e := range cfg.Endpoints {
+		err = e.Auth.validate()
+		if err != nil {
+			return fmt.Errorf("endpoint %d: Auth: %w", i, err)
+		}
+		if cfg.InsecureGRPC && e.Auth.usesTLS() {
+			return fmt.Errorf("endpoint %d: InsecureGRPC can't be combined with Auth TLS settings", i)
+		}
+		if cfg.UseBasicAuth && (len(e.Auth.headers()) > 0 || e.Auth.usesTLS()) {
+			return fmt.Errorf("endpoint %d: UseBasicAuth can't be combined with Auth", i)
+		}
+	}
+	if cfg.UseBasicAuth && (len(cfg.Auth.headers()) > 0 || cfg.Auth.usesTLS()) {
+		return errors.New("UseBasicAuth can't be combined with Auth")
+	}
+	if cfg.InsecureGRPC && cfg.Auth.usesTLS() {
+		return errors.New("InsecureGRPC can't be combined with Auth TLS settings")
+	}
+
+	err = cfg.Balancing.validate()
+	if err != nil {
+		return err
+	}
+
+	err = cfg.Retry.validate()
+	if err != nil {
+		return fmt.Errorf("Retry: %w", err)
+	}
+
+	err = cfg.RateLimit.validate()
+	if err != nil {
+		return fmt.Errorf("RateLimit: %w", err)
+	}
+	if cfg.CallTimeout < 0 {
+		return errors.New("negative CallTimeout")
+	}
+
+	err = cfg.Keyring.Validate()
+	if err != nil {
+		return fmt.Errorf("Keyring: %w", err)
+	}
+
+	err = validateSignMode(cfg.SignMode)
+	if err != nil {
+		return err
+	}
+
+	err = cfg.Bech32Prefixes.validate()
+	if err != nil {
+		return fmt.Errorf("Bech32Prefixes: %w", err)
+	}
+
+	if cfg.Denom != "" {
+		err = cosmosTypes.ValidateDenom(cfg.Denom)
+		if err != nil {
+			return fmt.Errorf("Denom: %w", err)
+		}
+	}
+	gasPrice, err := cosmosTypes.ParseDecCoin(cfg.gasPrice())
+	if err != nil {
+		return fmt.Errorf("GasPrice: %w", err)
+	}
+	if gasPrice.Denom != cfg.denom() {
+		return fmt.Errorf("GasPrice: denom %s differs from fee denom %s", gasPrice.Denom, cfg.denom())
 	}
 
 	return nil
 }
+
+// denom returns fee denom
+func (cfg *Config) denom() string {
+	if cfg.Denom == "" {
+		return DefaultDenom
+	}
+
+	return cfg.Denom
+}
+
+// gasPrice returns gas price with denom
+func (cfg *Config) gasPrice() string {
+	if cfg.GasPrice == "" {
+		return DefaultGasPrice + cfg.denom()
+	}
+
+	return cfg.GasPrice
+}
+
+// auth returns auth of the endpoint
+func (cfg *Config) auth(e Endpoint) *AuthConfig {
+	if e.Auth != nil {
+		return e.Auth
+	}
+
+	return &cfg.Auth
+}
+
+// endpoints returns GRPCHost and RPCHost as the first endpoint followed by Endpoints
+func (cfg *Config) endpoints() []Endpoint {
+	var res []Endpoint
+	if cfg.GRPCHost != "" || cfg.RPCHost != "" {
+		res = append(res, Endpoint{GRPCHost: cfg.GRPCHost, RPCHost: cfg.RPCHost})
+	}
+
+	return append(res, cfg.Endpoints...)
+}
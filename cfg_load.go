@@ -0,0 +1,419 @@
+package sdk
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// envPrefix is the prefix of environment variables read by LoadConfig and ConfigFromEnv
+const envPrefix = "SEI_"
+
+// signModeNames are the sign mode names of config files, same as seid --sign-mode values
+var signModeNames = map[string]signing.SignMode{
+	"direct":     signing.SignMode_SIGN_MODE_DIRECT,
+	"amino-json": signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+}
+
+type (
+	// fileConfig is Config as written in config files and environment variables. Durations are strings as "30s"
+	fileConfig struct {
+		// Network is the preset the other fields override
+		Network      string         `yaml:"network" toml:"network"`
+		ChainID      string         `yaml:"chain_id" toml:"chain_id"`
+		Bech32       fileBech32     `yaml:"bech32" toml:"bech32"`
+		Denom        string         `yaml:"denom" toml:"denom"`
+		GRPCHost     string         `yaml:"grpc_host" toml:"grpc_host"`
+		RPCHost      string         `yaml:"rpc_host" toml:"rpc_host"`
+		InsecureGRPC *bool          `yaml:"insecure_grpc" toml:"insecure_grpc"`
+		UseBasicAuth bool           `yaml:"use_basic_auth" toml:"use_basic_auth"`
+		Auth         fileAuth       `yaml:"auth" toml:"auth"`
+		GasPrice     string         `yaml:"gas_price" toml:"gas_price"`
+		SignMode     string         `yaml:"sign_mode" toml:"sign_mode"`
+		Endpoints    []fileEndpoint `yaml:"endpoints" toml:"endpoints"`
+		Balancing    string         `yaml:"balancing" toml:"balancing"`
+		CallTimeout  string         `yaml:"call_timeout" toml:"call_timeout"`
+
+		SkipChainIDCheck bool `yaml:"skip_chain_id_check" toml:"skip_chain_id_check"`
+
+		HealthCheck fileHealthCheck `yaml:"health_check" toml:"health_check"`
+		Retry       fileRetry       `yaml:"retry" toml:"retry"`
+		RateLimit   fileRateLimit   `yaml:"rate_limit" toml:"rate_limit"`
+		Keyring     fileKeyring     `yaml:"keyring" toml:"keyring"`
+	}
+	fileBech32 struct {
+		Account   string `yaml:"account" toml:"account"`
+		Validator string `yaml:"validator" toml:"validator"`
+		Consensus string `yaml:"consensus" toml:"consensus"`
+	}
+	fileEndpoint struct {
+		GRPCHost string    `yaml:"grpc_host" toml:"grpc_host"`
+		RPCHost  string    `yaml:"rpc_host" toml:"rpc_host"`
+		Auth     *fileAuth `yaml:"auth" toml:"auth"`
+	}
+	fileAuth struct {
+		Username    string            `yaml:"username" toml:"username"`
+		Password    string            `yaml:"password" toml:"password"`
+		BearerToken string            `yaml:"bearer_token" toml:"bearer_token"`
+		Headers     map[string]string `yaml:"headers" toml:"headers"`
+		CAFile      string            `yaml:"ca_file" toml:"ca_file"`
+		CertFile    string            `yaml:"cert_file" toml:"cert_file"`
+		KeyFile     string            `yaml:"key_file" toml:"key_file"`
+	}
+	fileHealthCheck struct {
+		Interval    string `yaml:"interval" toml:"interval"`
+		Timeout     string `yaml:"timeout" toml:"timeout"`
+		MaxBlockLag int64  `yaml:"max_block_lag" toml:"max_block_lag"`
+	}
+	fileRetry struct {
+		MaxAttempts    int     `yaml:"max_attempts" toml:"max_attempts"`
+		InitialBackoff string  `yaml:"initial_backoff" toml:"initial_backoff"`
+		MaxBackoff     string  `yaml:"max_backoff" toml:"max_backoff"`
+		Multiplier     float64 `yaml:"multiplier" toml:"multiplier"`
+		Jitter         float64 `yaml:"jitter" toml:"jitter"`
+		AttemptTimeout string  `yaml:"attempt_timeout" toml:"attempt_timeout"`
+	}
+	fileRateLimit struct {
+		RPS   float64 `yaml:"rps" toml:"rps"`
+		Burst int     `yaml:"burst" toml:"burst"`
+	}
+	fileKeyring struct {
+		Backend    string `yaml:"backend" toml:"backend"`
+		Dir        string `yaml:"dir" toml:"dir"`
+		Passphrase string `yaml:"passphrase" toml:"passphrase"`
+	}
+)
+
+// LoadConfig builds config from YAML (.yaml, .yml) or TOML (.toml) file, overridden by SEI_* environment variables,
+// see ConfigFromEnv. The file may select a network preset, which its other fields override:
+//
+//	network: pacific-1
+//	grpc_host: my-node:9090
+//	retry:
+//	  max_attempts: 5
+//	  attempt_timeout: 10s
+//
+// Unknown fields, malformed hosts and durations are reported along with Config.Validate errors
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	var fc fileConfig
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(&fc)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return Config{}, fmt.Errorf("parse %s: %w", path, err)
+		}
+	case ".toml":
+		dec := toml.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(&fc)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse %s: %w", path, err)
+		}
+	default:
+		return Config{}, fmt.Errorf("unsupported config file extension %s, expected .yaml, .yml or .toml", ext)
+	}
+
+	err = fc.applyEnv(os.LookupEnv)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return fc.config()
+}
+
+// ConfigFromEnv builds config from environment variables:
+//
+//	SEI_NETWORK                preset: pacific-1, atlantic-2, arctic-1 or local
+//	SEI_CHAIN_ID               chain ID
+//	SEI_BECH32_PREFIX          account address prefix, validator and consensus prefixes are derived from it
+//	SEI_DENOM                  fee denom
+//	SEI_GRPC_HOST              gRPC host:port
+//	SEI_RPC_HOST               RPC URL
+//	SEI_INSECURE_GRPC          true to connect to gRPC without TLS
+//	SEI_USE_BASIC_AUTH         true to use basic auth from the host URLs
+//	SEI_AUTH_USERNAME          basic auth username
+//	SEI_AUTH_PASSWORD          basic auth password
+//	SEI_AUTH_BEARER_TOKEN      bearer auth token
+//	SEI_AUTH_HEADERS           comma separated name=value headers, e.g. x-api-key=...
+//	SEI_AUTH_CA_FILE           PEM bundle of CAs verifying node certificates
+//	SEI_AUTH_CERT_FILE         PEM client certificate for mTLS
+//	SEI_AUTH_KEY_FILE          PEM client key for mTLS
+//	SEI_GAS_PRICE              gas price with denom, e.g. 0.1usei
+//	SEI_SIGN_MODE              direct or amino-json
+//	SEI_ENDPOINTS              comma separated grpc_host=rpc_host pairs of additional endpoints
+//	SEI_BALANCING              round-robin or least-latency
+//	SEI_CALL_TIMEOUT           deadline of a query, e.g. 30s
+//	SEI_RETRY_MAX_ATTEMPTS     query attempts, 1 disables retries
+//	SEI_RETRY_ATTEMPT_TIMEOUT  deadline of a single attempt
+//	SEI_RATE_LIMIT_RPS         requests per second sent to every endpoint
+//	SEI_RATE_LIMIT_BURST       requests sent at once
+//	SEI_SKIP_CHAIN_ID_CHECK    true to skip the check that the nodes are on the chain ID network
+//	SEI_KEYRING_BACKEND        memory, file or test
+//	SEI_KEYRING_DIR            keyring root dir
+//	SEI_KEYRING_PASSPHRASE     file keyring passphrase
+func ConfigFromEnv() (Config, error) {
+	var fc fileConfig
+	err := fc.applyEnv(os.LookupEnv)
+	if err != nil {
+		return Config{}, err
+	}
+
+	return fc.config()
+}
+
+// applyEnv overrides fields set by environment variables
+func (fc *fileConfig) applyEnv(lookup func(string) (string, bool)) error {
+	str := func(v *string) func(string) error {
+		return func(s string) error {
+			*v = s
+			return nil
+		}
+	}
+	vars := []struct {
+		name string
+		set  func(string) error
+	}{
+		{"NETWORK", str(&fc.Network)},
+		{"CHAIN_ID", str(&fc.ChainID)},
+		{"BECH32_PREFIX", str(&fc.Bech32.Account)},
+		{"DENOM", str(&fc.Denom)},
+		{"GRPC_HOST", str(&fc.GRPCHost)},
+		{"RPC_HOST", str(&fc.RPCHost)},
+		{"INSECURE_GRPC", func(s string) error {
+			v, err := strconv.ParseBool(s)
+			fc.InsecureGRPC = &v
+			return err
+		}},
+		{"SKIP_CHAIN_ID_CHECK", func(s string) (err error) {
+			fc.SkipChainIDCheck, err = strconv.ParseBool(s)
+			return err
+		}},
+		{"USE_BASIC_AUTH", func(s string) (err error) {
+			fc.UseBasicAuth, err = strconv.ParseBool(s)
+			return err
+		}},
+		{"AUTH_USERNAME", str(&fc.Auth.Username)},
+		{"AUTH_PASSWORD", str(&fc.Auth.Password)},
+		{"AUTH_BEARER_TOKEN", str(&fc.Auth.BearerToken)},
+		{"AUTH_HEADERS", func(s string) error {
+			fc.Auth.Headers = make(map[string]string)
+			for _, pair := range strings.Split(s, ",") {
+				name, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok {
+					return fmt.Errorf("header %q is not name=value", pair)
+				}
+				fc.Auth.Headers[name] = value
+			}
+			return nil
+		}},
+		{"AUTH_CA_FILE", str(&fc.Auth.CAFile)},
+		{"AUTH_CERT_FILE", str(&fc.Auth.CertFile)},
+		{"AUTH_KEY_FILE", str(&fc.Auth.KeyFile)},
+		{"GAS_PRICE", str(&fc.GasPrice)},
+		{"SIGN_MODE", str(&fc.SignMode)},
+		{"ENDPOINTS", func(s string) error {
+			fc.Endpoints = nil
+			for _, pair := range strings.Split(s, ",") {
+				grpcHost, rpcHost, ok := strings.Cut(strings.TrimSpace(pair), "=")
+				if !ok {
+					return fmt.Errorf("endpoint %q is not grpc_host=rpc_host", pair)
+				}
+				fc.Endpoints = append(fc.Endpoints, fileEndpoint{GRPCHost: grpcHost, RPCHost: rpcHost})
+			}
+			return nil
+		}},
+		{"BALANCING", str(&fc.Balancing)},
+		{"CALL_TIMEOUT", str(&fc.CallTimeout)},
+		{"RETRY_MAX_ATTEMPTS", func(s string) (err error) {
+			fc.Retry.MaxAttempts, err = strconv.Atoi(s)
+			return err
+		}},
+		{"RETRY_ATTEMPT_TIMEOUT", str(&fc.Retry.AttemptTimeout)},
+		{"RATE_LIMIT_RPS", func(s string) (err error) {
+			fc.RateLimit.RPS, err = strconv.ParseFloat(s, 64)
+			return err
+		}},
+		{"RATE_LIMIT_BURST", func(s string) (err error) {
+			fc.RateLimit.Burst, err = strconv.Atoi(s)
+			return err
+		}},
+		{"KEYRING_BACKEND", str(&fc.Keyring.Backend)},
+		{"KEYRING_DIR", str(&fc.Keyring.Dir)},
+		{"KEYRING_PASSPHRASE", str(&fc.Keyring.Passphrase)},
+	}
+
+	var errs []error
+	for _, v := range vars {
+		s, ok := lookup(envPrefix + v.name)
+		if !ok {
+			continue
+		}
+		err := v.set(s)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s%s: %w", envPrefix, v.name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// config converts the file config to validated Config, reporting all found errors
+func (fc *fileConfig) config() (Config, error) {
+	var (
+		cfg  Config
+		errs []error
+	)
+	if fc.Network != "" {
+		n, err := NetworkByName(fc.Network)
+		if err != nil {
+			return Config{}, fmt.Errorf("network: %w", err)
+		}
+		cfg = n.Config()
+
+		if fc.ChainID != "" && fc.ChainID != string(n.ChainID) {
+			errs = append(errs, fmt.Errorf("chain_id %s does not match network %s chain ID %s", fc.ChainID, fc.Network, n.ChainID))
+		}
+	}
+
+	override(&cfg.ChainID, ChainID(fc.ChainID))
+	cfg.Bech32Prefixes = Bech32Prefixes{Account: fc.Bech32.Account, Validator: fc.Bech32.Validator, Consensus: fc.Bech32.Consensus}
+	if fc.Denom != "" && fc.Denom != cfg.Denom {
+		// gas price of the preset is in its denom
+		cfg.Denom, cfg.GasPrice = fc.Denom, ""
+	}
+	override(&cfg.GRPCHost, fc.GRPCHost)
+	override(&cfg.RPCHost, fc.RPCHost)
+	if fc.InsecureGRPC != nil {
+		cfg.InsecureGRPC = *fc.InsecureGRPC
+	}
+	cfg.UseBasicAuth = fc.UseBasicAuth
+	cfg.SkipChainIDCheck = fc.SkipChainIDCheck
+	cfg.Auth = fc.Auth.config()
+	override(&cfg.GasPrice, fc.GasPrice)
+	for _, e := range fc.Endpoints {
+		endpoint := Endpoint{GRPCHost: e.GRPCHost, RPCHost: e.RPCHost}
+		if e.Auth != nil {
+			auth := e.Auth.config()
+			endpoint.Auth = &auth
+		}
+		cfg.Endpoints = append(cfg.Endpoints, endpoint)
+	}
+	cfg.Balancing = BalancingStrategy(fc.Balancing)
+	cfg.HealthCheck.MaxBlockLag = fc.HealthCheck.MaxBlockLag
+	cfg.Retry.MaxAttempts = fc.Retry.MaxAttempts
+	cfg.Retry.Multiplier = fc.Retry.Multiplier
+	cfg.Retry.Jitter = fc.Retry.Jitter
+	cfg.RateLimit = RateLimit{RPS: fc.RateLimit.RPS, Burst: fc.RateLimit.Burst}
+	cfg.Keyring = KeyringConfig{Backend: fc.Keyring.Backend, Dir: fc.Keyring.Dir, Passphrase: fc.Keyring.Passphrase}
+
+	if fc.SignMode != "" {
+		mode, ok := signModeNames[fc.SignMode]
+		if !ok {
+			errs = append(errs, fmt.Errorf("sign_mode: unknown sign mode %s, expected direct or amino-json", fc.SignMode))
+		}
+		cfg.SignMode = mode
+	}
+
+	durations := []struct {
+		field string
+		value string
+		dst   *time.Duration
+	}{
+		{"call_timeout", fc.CallTimeout, &cfg.CallTimeout},
+		{"health_check.interval", fc.HealthCheck.Interval, &cfg.HealthCheck.Interval},
+		{"health_check.timeout", fc.HealthCheck.Timeout, &cfg.HealthCheck.Timeout},
+		{"retry.initial_backoff", fc.Retry.InitialBackoff, &cfg.Retry.InitialBackoff},
+		{"retry.max_backoff", fc.Retry.MaxBackoff, &cfg.Retry.MaxBackoff},
+		{"retry.attempt_timeout", fc.Retry.AttemptTimeout, &cfg.Retry.AttemptTimeout},
+	}
+	for _, d := range durations {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", d.field, err))
+		}
+		*d.dst = v
+	}
+
+	if cfg.ChainID == "" {
+		errs = append(errs, errors.New("chain_id: empty, set it or select a network"))
+	}
+	errs = append(errs, validateHosts(&cfg)...)
+	if len(errs) > 0 {
+		return Config{}, errors.Join(errs...)
+	}
+
+	err := cfg.Validate()
+	if err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+func (fa fileAuth) config() AuthConfig {
+	return AuthConfig{
+		Username:    fa.Username,
+		Password:    fa.Password,
+		BearerToken: fa.BearerToken,
+		Headers:     fa.Headers,
+		CAFile:      fa.CAFile,
+		CertFile:    fa.CertFile,
+		KeyFile:     fa.KeyFile,
+	}
+}
+
+// validateHosts checks that gRPC hosts are host:port and RPC hosts are URLs
+func validateHosts(cfg *Config) (errs []error) {
+	for i, e := range cfg.endpoints() {
+		if e.GRPCHost != "" && !cfg.UseBasicAuth {
+			_, port, err := net.SplitHostPort(e.GRPCHost)
+			if err != nil || port == "" {
+				errs = append(errs, fmt.Errorf("endpoint %d: GRPCHost %s is not host:port", i, e.GRPCHost))
+			}
+		}
+		if e.RPCHost != "" {
+			u, err := url.Parse(e.RPCHost)
+			if err != nil || u.Host == "" {
+				errs = append(errs, fmt.Errorf("endpoint %d: RPCHost %s is not an URL", i, e.RPCHost))
+				continue
+			}
+			switch u.Scheme {
+			case "http", "https", "tcp":
+			default:
+				errs = append(errs, fmt.Errorf("endpoint %d: RPCHost %s has unsupported scheme %s", i, e.RPCHost, u.Scheme))
+			}
+		}
+	}
+
+	return errs
+}
+
+// override sets the value when the override is not empty
+func override[T comparable](value *T, with T) {
+	var zero T
+	if with != zero {
+		*value = with
+	}
+}
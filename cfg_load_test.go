@@ -0,0 +1,172 @@
+package sdk
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"gotest.tools/assert"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoadConfig_YAML(t *testing.T) {
+	path := writeConfigFile(t, "sei.yaml", `
+network: pacific-1
+grpc_host: my-node:9090
+insecure_grpc: true
+sign_mode: amino-json
+endpoints:
+  - grpc_host: grpc.sei-apis.com:443
+    rpc_host: https://rpc.sei-apis.com
+balancing: least-latency
+call_timeout: 30s
+retry:
+  max_attempts: 5
+  attempt_timeout: 10s
+rate_limit:
+  rps: 10
+`)
+
+	cfg, err := LoadConfig(path)
+	assert.NilError(t, err)
+	assert.Equal(t, cfg.ChainID, ChainIDMainnet)
+	assert.Equal(t, cfg.GRPCHost, "my-node:9090")
+	assert.Equal(t, cfg.RPCHost, NetworkMainnet.RPCHost)
+	assert.Equal(t, cfg.InsecureGRPC, true)
+	assert.Equal(t, cfg.GasPrice, DefaultGasPriceWithDenom)
+	assert.Equal(t, cfg.SignMode, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+	assert.DeepEqual(t, cfg.Endpoints, []Endpoint{{GRPCHost: "grpc.sei-apis.com:443", RPCHost: "https://rpc.sei-apis.com"}})
+	assert.Equal(t, cfg.Balancing, BalanceLeastLatency)
+	assert.Equal(t, cfg.CallTimeout, 30*time.Second)
+	assert.DeepEqual(t, cfg.Retry, RetryPolicy{MaxAttempts: 5, AttemptTimeout: 10 * time.Second})
+	assert.Equal(t, cfg.RateLimit, RateLimit{RPS: 10})
+}
+
+func TestLoadConfig_TOML(t *testing.T) {
+	path := writeConfigFile(t, "sei.toml", `
+chain_id = "my-fork-1"
+grpc_host = "localhost:9090"
+rpc_host = "http://localhost:26657"
+insecure_grpc = true
+gas_price = "0.02usei"
+
+[auth]
+bearer_token = "token"
+headers = { x-api-key = "key" }
+
+[[endpoints]]
+grpc_host = "localhost:9091"
+rpc_host = "http://localhost:26658"
+auth = { username = "user", password = "pass" }
+
+[keyring]
+backend = "test"
+dir = "/var/lib/sei"
+`)
+
+	cfg, err := LoadConfig(path)
+	assert.NilError(t, err)
+	assert.Equal(t, cfg.ChainID, ChainID("my-fork-1"))
+	assert.Equal(t, cfg.GasPrice, "0.02usei")
+	assert.Equal(t, cfg.InsecureGRPC, true)
+	assert.DeepEqual(t, cfg.Keyring, KeyringConfig{Backend: KeyringBackendTest, Dir: "/var/lib/sei"})
+	assert.DeepEqual(t, cfg.Auth, AuthConfig{BearerToken: "token", Headers: map[string]string{"x-api-key": "key"}})
+	assert.DeepEqual(t, cfg.Endpoints[0].Auth, &AuthConfig{Username: "user", Password: "pass"})
+}
+
+func TestLoadConfig_Env(t *testing.T) {
+	path := writeConfigFile(t, "sei.yml", "network: atlantic-2\n")
+	t.Setenv("SEI_GRPC_HOST", "my-node:9090")
+	t.Setenv("SEI_ENDPOINTS", "grpc-2:9090=http://rpc-2:26657, grpc-3:9090=http://rpc-3:26657")
+	t.Setenv("SEI_RETRY_MAX_ATTEMPTS", "1")
+
+	cfg, err := LoadConfig(path)
+	assert.NilError(t, err)
+	assert.Equal(t, cfg.ChainID, ChainIDTestnet)
+	assert.Equal(t, cfg.GRPCHost, "my-node:9090")
+	assert.Equal(t, len(cfg.Endpoints), 2)
+	assert.Equal(t, cfg.Endpoints[1].RPCHost, "http://rpc-3:26657")
+	assert.Equal(t, cfg.Retry.MaxAttempts, 1)
+
+	t.Setenv("SEI_RETRY_MAX_ATTEMPTS", "many")
+	_, err = LoadConfig(path)
+	assert.ErrorContains(t, err, "SEI_RETRY_MAX_ATTEMPTS")
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("SEI_NETWORK", "local")
+	t.Setenv("SEI_GAS_PRICE", "1usei")
+	t.Setenv("SEI_SKIP_CHAIN_ID_CHECK", "true")
+
+	cfg, err := ConfigFromEnv()
+	assert.NilError(t, err)
+	assert.Equal(t, cfg.ChainID, ChainIDLocal)
+	assert.Equal(t, cfg.SkipChainIDCheck, true)
+	assert.Equal(t, cfg.GRPCHost, "localhost:9090")
+	assert.Equal(t, cfg.InsecureGRPC, true)
+	assert.Equal(t, cfg.GasPrice, "1usei")
+
+	// gas price of the preset is replaced by the default gas price of the denom
+	t.Setenv("SEI_GAS_PRICE", "")
+	t.Setenv("SEI_DENOM", "ufork")
+	t.Setenv("SEI_BECH32_PREFIX", "fork")
+	cfg, err = ConfigFromEnv()
+	assert.NilError(t, err)
+	assert.Equal(t, cfg.gasPrice(), "0.1ufork")
+	assert.Equal(t, cfg.Bech32Prefixes.Account, "fork")
+
+	t.Setenv("SEI_NETWORK", "mainnet")
+	_, err = ConfigFromEnv()
+	assert.ErrorContains(t, err, "unknown network mainnet, known networks are [arctic-1 atlantic-2 local pacific-1]")
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	_, err := LoadConfig(writeConfigFile(t, "sei.yaml", "network: pacific-1\ngrpc_hots: my-node:9090\n"))
+	assert.ErrorContains(t, err, "field grpc_hots not found")
+
+	_, err = LoadConfig(writeConfigFile(t, "sei.toml", "network = \"pacific-1\"\ngrpc_hots = \"my-node:9090\"\n"))
+	assert.ErrorContains(t, err, "strict mode")
+
+	_, err = LoadConfig(writeConfigFile(t, "sei.json", "{}"))
+	assert.ErrorContains(t, err, "unsupported config file extension .json")
+
+	// all errors are reported at once
+	_, err = LoadConfig(writeConfigFile(t, "sei.yaml", `
+network: pacific-1
+chain_id: atlantic-2
+grpc_host: my-node
+rpc_host: rpc.example.com
+sign_mode: textual
+call_timeout: 30
+`))
+	assert.ErrorContains(t, err, "chain_id atlantic-2 does not match network pacific-1 chain ID pacific-1")
+	assert.ErrorContains(t, err, "GRPCHost my-node is not host:port")
+	assert.ErrorContains(t, err, "RPCHost rpc.example.com is not an URL")
+	assert.ErrorContains(t, err, "sign_mode: unknown sign mode textual")
+	assert.ErrorContains(t, err, "call_timeout: time: missing unit")
+
+	_, err = LoadConfig(writeConfigFile(t, "sei.yaml", "grpc_host: localhost:9090\nrpc_host: http://localhost:26657\n"))
+	assert.ErrorContains(t, err, "chain_id: empty")
+
+	_, err = LoadConfig(writeConfigFile(t, "sei.yaml", "network: local\ngas_price: cheap\n"))
+	assert.ErrorContains(t, err, "GasPrice:")
+}
+
+func TestNetworks(t *testing.T) {
+	for _, name := range NetworkNames() {
+		n, err := NetworkByName(name)
+		assert.NilError(t, err)
+
+		cfg := n.Config()
+		assert.NilError(t, cfg.Validate(), name)
+		assert.Equal(t, len(validateHosts(&cfg)), 0, name)
+	}
+}
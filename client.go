@@ -1,21 +1,20 @@
 package sdk
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/cosmos/cosmos-sdk/client"
 	txf "github.com/cosmos/cosmos-sdk/client/tx"
-	"github.com/cosmos/cosmos-sdk/codec"
 	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
-	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	"github.com/cosmos/cosmos-sdk/std"
 	cosmosTypes "github.com/cosmos/cosmos-sdk/types"
 	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
-	"github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
 	authztypes "github.com/cosmos/cosmos-sdk/x/authz"
@@ -28,11 +27,14 @@ import (
 	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	"google.golang.org/grpc"
 )
 
 const (
+	// DefaultGasPrice defines the default gas price of the fee denom
+	DefaultGasPrice = "0.1"
 	// DefaultGasPriceWithDenom defines the default gas price denomination
-	DefaultGasPriceWithDenom = "0.1usei"
+	DefaultGasPriceWithDenom = DefaultGasPrice + DefaultDenom
 	// Bech32PrefixAccAddr defines the Bech32 prefix for account addresses
 	Bech32PrefixAccAddr = "sei"
 	// Bech32PrefixAccPub defines the Bech32 prefix for account public keys
@@ -45,18 +47,22 @@ type Client struct {
 	txClient        txtypes.ServiceClient
 	wasmQueryClient wasmtypes.QueryClient
 	bankQueryClient banktypes.QueryClient
+	authQueryClient authtypes.QueryClient
 
-	signers   map[string]signer
+	signers   *signerRegistry
 	clientCtx client.Context
 	txFactory txf.Factory
-
-	canSign bool
+	endpoints *endpointPool
+	telemetry *telemetry
+	logger    *slog.Logger
 }
 
 // signer holds information about a signer
 type signer struct {
-	address cosmosTypes.Address
-	name    string
+	address    cosmosTypes.Address
+	evmAddress string
+	name       string
+	key        Signer
 }
 
 // NewClient creates a new Cosmos SDK client
@@ -66,9 +72,10 @@ func NewClient(cfg Config) (c *Client, err error) {
 		return nil, err
 	}
 
-	config := cosmosTypes.GetConfig()
-	config.SetBech32PrefixForAccount(Bech32PrefixAccAddr, Bech32PrefixAccPub)
-	config.Seal()
+	err = configureBech32(cfg.Bech32Prefixes)
+	if err != nil {
+		return nil, err
+	}
 
 	interfaceRegistry := codecTypes.NewInterfaceRegistry()
 	std.RegisterInterfaces(interfaceRegistry)
@@ -84,20 +91,47 @@ func NewClient(cfg Config) (c *Client, err error) {
 	stakingtypes.RegisterInterfaces(interfaceRegistry)
 	upgradetypes.RegisterInterfaces(interfaceRegistry)
 	feegranttypes.RegisterInterfaces(interfaceRegistry)
+	wasmtypes.RegisterInterfaces(interfaceRegistry)
 
-	tmClient, err := client.NewClientFromNode(cfg.RPCHost)
+	logger := newLogger(cfg)
+	tel, err := newTelemetry(cfg.Telemetry, logger)
 	if err != nil {
-		return nil, fmt.Errorf("NewClientFromNode: %s", err)
+		return nil, fmt.Errorf("newTelemetry: %w", err)
 	}
 
+	pool, err := connectEndpoints(cfg, tel, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.SkipChainIDCheck {
+		err = pool.verifyChainID(context.Background())
+		if err != nil {
+			pool.close()
+			return nil, err
+		}
+	}
+
+	kr, err := newKeyring(cfg.Keyring)
+	if err != nil {
+		pool.close()
+		return nil, fmt.Errorf("newKeyring: %w", err)
+	}
+
+	legacyAmino := newLegacyAmino()
 	clientCtx := client.Context{}.
-		WithTxConfig(tx.NewTxConfig(codec.NewProtoCodec(interfaceRegistry), []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT})).
+		WithTxConfig(newTxConfig(interfaceRegistry, legacyAmino)).
+		WithLegacyAmino(legacyAmino).
 		WithChainID(string(cfg.ChainID)).
-		WithKeyring(keyring.NewInMemory()).
+		WithKeyring(kr).
 		WithAccountRetriever(authtypes.AccountRetriever{}).
-		WithClient(tmClient).
+		WithClient(newPoolRPC(pool)).
 		WithInterfaceRegistry(interfaceRegistry)
 
+	signMode := cfg.SignMode
+	if signMode == signing.SignMode_SIGN_MODE_UNSPECIFIED {
+		signMode = signing.SignMode_SIGN_MODE_DIRECT
+	}
 	txFactory := txf.Factory{}.
 		WithKeybase(clientCtx.Keyring).
 		WithTxConfig(clientCtx.TxConfig).
@@ -105,28 +139,64 @@ func NewClient(cfg Config) (c *Client, err error) {
 		WithSimulateAndExecute(true).
 		WithGasAdjustment(1.1).
 		WithChainID(clientCtx.ChainID).
-		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT).
-		WithGasPrices(DefaultGasPriceWithDenom)
+		WithSignMode(signMode).
+		WithGasPrices(cfg.gasPrice())
+
+	c = &Client{
+		txFactory:       txFactory,
+		txClient:        txtypes.NewServiceClient(pool),
+		wasmQueryClient: wasmtypes.NewQueryClient(pool),
+		bankQueryClient: banktypes.NewQueryClient(pool),
+		authQueryClient: authtypes.NewQueryClient(pool),
 
-	conn, err := getGRPCConn(cfg)
+		clientCtx: clientCtx,
+		signers:   newSignerRegistry(),
+		endpoints: pool,
+		telemetry: tel,
+		logger:    logger,
+	}
+
+	err = c.loadSigners()
 	if err != nil {
-		return nil, fmt.Errorf("getGRPCConn: %s", err)
+		pool.close()
+		return nil, fmt.Errorf("loadSigners: %w", err)
 	}
 
-	return &Client{
-		txFactory:       txFactory,
-		txClient:        txtypes.NewServiceClient(conn),
-		wasmQueryClient: wasmtypes.NewQueryClient(conn),
-		bankQueryClient: banktypes.NewQueryClient(conn),
+	c.log().Info("client created", "chain_id", cfg.ChainID, "endpoints", len(pool.endpoints), "chain_id_verified", !cfg.SkipChainIDCheck)
 
-		clientCtx: clientCtx,
-		signers:   make(map[string]signer),
-	}, nil
+	return c, nil
 }
 
-// GetSignerAddresses returns a list of addresses for every added signer
+// connectEndpoints connects to every configured endpoint. Requests to the endpoints are retried, rate limited
+// and observed by the telemetry with the same interceptors for gRPC and RPC
+func connectEndpoints(cfg Config, tel *telemetry, logger *slog.Logger) (*endpointPool, error) {
+	var endpoints []*endpoint
+	for _, e := range cfg.endpoints() {
+		tmClient, err := getRPCClient(e.RPCHost, cfg.auth(e))
+		if err != nil {
+			newEndpointPool(endpoints, cfg, logger).close()
+			return nil, fmt.Errorf("getRPCClient: %s", err)
+		}
+
+		intercept := endpointInterceptor(cfg, logger)
+		conn, err := getGRPCConn(cfg, e, grpc.WithChainUnaryInterceptor(intercept, tel.unaryInterceptor(transportGRPC, e.GRPCHost)))
+		if err != nil {
+			newEndpointPool(endpoints, cfg, logger).close()
+			return nil, fmt.Errorf("getGRPCConn: %s", err)
+		}
+
+		ep := newEndpoint(e, conn, tmClient)
+		ep.intercept = chainUnaryInterceptors(intercept, tel.unaryInterceptor(transportRPC, e.RPCHost))
+		endpoints = append(endpoints, ep)
+		logger.Debug("endpoint connected", "grpc_host", e.GRPCHost, "rpc_host", e.RPCHost, "insecure_grpc", cfg.InsecureGRPC)
+	}
+
+	return newEndpointPool(endpoints, cfg, logger), nil
+}
+
+// GetSignerAddresses returns a list of addresses for every added signer sorted by signer name
 func (c *Client) GetSignerAddresses() (res []string) {
-	for _, s := range c.signers {
+	for _, s := range c.signers.list() {
 		res = append(res, s.address.String())
 	}
 
@@ -135,39 +205,59 @@ func (c *Client) GetSignerAddresses() (res []string) {
 
 // getSigner returns signer by name
 func (c *Client) getSigner(name string) (signer, error) {
-	sgn, ok := c.signers[name]
-	if !ok {
-		return signer{}, fmt.Errorf("signer with name %s not added", name)
-	}
+	return c.signers.get(name)
+}
 
-	return sgn, nil
+// acquireSigner returns signer by name, keeping it from being rotated or removed until release is called
+func (c *Client) acquireSigner(name string) (signer, func(), error) {
+	return c.signers.acquire(name)
 }
 
 // AddSigner adds signer by name, so it can be later used for signing
 func (c *Client) AddSigner(name, mnemonic string) (string, error) {
+	return c.AddSignerWithOptions(name, mnemonic, KeyOptions{})
+}
+
+// AddSignerWithOptions adds signer by name, deriving its key with the given HD options
+func (c *Client) AddSignerWithOptions(name, mnemonic string, opts KeyOptions) (string, error) {
+	sgn, err := c.addMnemonicSigner(name, mnemonic, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return sgn.address.String(), nil
+}
+
+// addMnemonicSigner creates keyring key derived from the mnemonic and registers it as signer
+func (c *Client) addMnemonicSigner(name, mnemonic string, opts KeyOptions) (signer, error) {
 	if name == "" {
-		return "", errors.New("empty name")
+		return signer{}, errors.New("empty name")
 	}
 	if mnemonic == "" {
-		return "", errors.New("empty mnemonic")
+		return signer{}, errors.New("empty mnemonic")
 	}
 
-	if _, ok := c.signers[name]; ok {
-		return "", fmt.Errorf("duplicate signer %s", name)
+	e, err := c.signers.reserve(name)
+	if err != nil {
+		return signer{}, err
 	}
 
-	path := hd.CreateHDPath(118, 0, 0).String()
-	signerInfo, err := c.clientCtx.Keyring.NewAccount(name, mnemonic, "", path, hd.Secp256k1)
+	sgn, err := c.newMnemonicKey(name, mnemonic, opts)
 	if err != nil {
-		return "", fmt.Errorf("NewAccount: %w", err)
+		c.signers.cancel(e)
+		return signer{}, err
 	}
+	c.signers.commit(e, sgn)
 
-	addr := signerInfo.GetAddress()
-	c.signers[name] = signer{
-		address: addr,
-		name:    name,
+	return sgn, nil
+}
+
+// newMnemonicKey creates keyring key derived from the mnemonic
+func (c *Client) newMnemonicKey(name, mnemonic string, opts KeyOptions) (signer, error) {
+	_, err := c.clientCtx.Keyring.NewAccount(name, mnemonic, opts.Passphrase, opts.hdPath(), hd.Secp256k1)
+	if err != nil {
+		return signer{}, fmt.Errorf("NewAccount: %w", err)
 	}
-	c.canSign = true
 
-	return addr.String(), nil
+	return c.newKeyringSigner(name)
 }
@@ -0,0 +1,155 @@
+package sdk
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+// TxRecord is a decoded transaction together with its execution result
+type TxRecord struct {
+	Hash      string
+	Height    int64
+	Index     uint32
+	BlockTime time.Time
+
+	Code      uint32
+	Codespace string
+	Log       string
+	GasWanted int64
+	GasUsed   int64
+
+	Fee     sdktypes.Coins
+	Memo    string
+	Signers []string
+
+	Messages []TxMessage
+	// Events contains every event emitted by the tx
+	Events []abci.Event
+}
+
+// TxMessage is a decoded tx message with the events it emitted
+type TxMessage struct {
+	Index   int
+	TypeURL string
+	Msg     sdktypes.Msg
+	// JSON holds the contract payload of wasm instantiate, execute and migrate messages
+	JSON json.RawMessage
+	// Events are taken from the tx log, they are empty for failed txs
+	Events sdktypes.StringEvents
+}
+
+// DecodeTx decodes tx body and groups its events by message. BlockTime is not filled as it requires a separate request
+func (c *Client) DecodeTx(tx *coretypes.ResultTx) (*TxRecord, error) {
+	decoded, err := c.clientCtx.TxConfig.TxDecoder()(tx.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("TxDecoder: %w", err)
+	}
+
+	record := &TxRecord{
+		Hash:      strings.ToUpper(hex.EncodeToString(tx.Hash)),
+		Height:    tx.Height,
+		Index:     tx.Index,
+		Code:      tx.TxResult.Code,
+		Codespace: tx.TxResult.Codespace,
+		Log:       tx.TxResult.Log,
+		GasWanted: tx.TxResult.GasWanted,
+		GasUsed:   tx.TxResult.GasUsed,
+		Events:    tx.TxResult.Events,
+	}
+
+	if feeTx, ok := decoded.(sdktypes.FeeTx); ok {
+		record.Fee = feeTx.GetFee()
+	}
+	if memoTx, ok := decoded.(sdktypes.TxWithMemo); ok {
+		record.Memo = memoTx.GetMemo()
+	}
+
+	// logs are only written for successful txs
+	var logs sdktypes.ABCIMessageLogs
+	if tx.TxResult.Code == 0 {
+		logs, _ = sdktypes.ParseABCILogs(tx.TxResult.Log)
+	}
+
+	seenSigners := make(map[string]struct{})
+	for i, msg := range decoded.GetMsgs() {
+		for _, s := range msg.GetSigners() {
+			if _, ok := seenSigners[s.String()]; !ok {
+				seenSigners[s.String()] = struct{}{}
+				record.Signers = append(record.Signers, s.String())
+			}
+		}
+
+		message := TxMessage{
+			Index:   i,
+			TypeURL: sdktypes.MsgTypeURL(msg),
+			Msg:     msg,
+			JSON:    contractPayload(msg),
+		}
+		for _, l := range logs {
+			if int(l.MsgIndex) == i {
+				message.Events = l.Events
+			}
+		}
+		record.Messages = append(record.Messages, message)
+	}
+
+	return record, nil
+}
+
+// contractPayload returns json payload of wasm messages
+func contractPayload(msg sdktypes.Msg) json.RawMessage {
+	switch m := msg.(type) {
+	case *wasmtypes.MsgExecuteContract:
+		return json.RawMessage(m.Msg)
+	case *wasmtypes.MsgInstantiateContract:
+		return json.RawMessage(m.Msg)
+	case *wasmtypes.MsgMigrateContract:
+		return json.RawMessage(m.Msg)
+	default:
+		return nil
+	}
+}
+
+// headerFetcher is the part of the RPC client used for block header retrieval
+type headerFetcher interface {
+	Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error)
+}
+
+// blockTimeCache remembers the time of the last requested block, as txs are processed in height order
+type blockTimeCache struct {
+	node headerFetcher
+
+	height int64
+	time   time.Time
+}
+
+func newBlockTimeCache(node headerFetcher) *blockTimeCache {
+	return &blockTimeCache{node: node}
+}
+
+func (b *blockTimeCache) get(ctx context.Context, height int64) (time.Time, error) {
+	if b.height == height {
+		return b.time, nil
+	}
+
+	resp, err := b.node.Header(ctx, &height)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Header: %w", err)
+	}
+	if resp.Header == nil {
+		return time.Time{}, fmt.Errorf("empty header at height %d", height)
+	}
+
+	b.height, b.time = height, resp.Header.Time
+
+	return b.time, nil
+}
@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"gotest.tools/assert"
+)
+
+// withContractTxs replaces tx bodies of the stub with execute messages of the sender, the message of tx i is {"n":i}
+func withContractTxs(t *testing.T, s *stubRPC, c *Client, sender sdktypes.AccAddress) {
+	for i, tx := range s.txs {
+		msg := &wasmtypes.MsgExecuteContract{Sender: sender.String(), Contract: testContractAddress, Msg: []byte(fmt.Sprintf(`{"n":%d}`, i))}
+		txn := c.clientCtx.TxConfig.NewTxBuilder()
+		assert.NilError(t, txn.SetMsgs(msg))
+		txn.SetMemo("memo " + strconv.Itoa(i))
+		txn.SetFeeAmount(sdktypes.NewCoins(sdktypes.NewInt64Coin("usei", 100)))
+
+		var err error
+		tx.Tx, err = c.clientCtx.TxConfig.TxEncoder()(txn.GetTx())
+		assert.NilError(t, err)
+		tx.TxResult.Log = sdktypes.ABCIMessageLogs{{MsgIndex: 0, Events: sdktypes.StringifyEvents(tx.TxResult.Events)}}.String()
+	}
+}
+
+func TestClient_HandleDecodedTxsByHeight(t *testing.T) {
+	stub := newStubRPC(t, 5, 5, 6)
+	c := newStubClient(t, stub)
+	sender := sdktypes.AccAddress("sender______________")
+	withContractTxs(t, stub, c, sender)
+
+	var records []*TxRecord
+	err := c.HandleDecodedTxsByHeight(context.Background(), testContractAddress, 1, 10, func(_ context.Context, tx *TxRecord) error {
+		records = append(records, tx)
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(records), 3)
+
+	for i, record := range records {
+		assert.Equal(t, record.Hash, fmt.Sprintf("%X", stub.txs[i].Hash))
+		assert.Equal(t, record.Height, stub.txs[i].Height)
+		assert.Equal(t, record.BlockTime, time.Unix(stub.txs[i].Height, 0).UTC())
+		assert.Equal(t, record.Memo, "memo "+strconv.Itoa(i))
+		assert.Equal(t, record.Fee.String(), "100usei")
+		assert.DeepEqual(t, record.Signers, []string{sender.String()})
+		assert.Equal(t, len(record.Messages), 1)
+		assert.Equal(t, record.Messages[0].TypeURL, "/cosmwasm.wasm.v1.MsgExecuteContract")
+		assert.Equal(t, string(record.Messages[0].JSON), fmt.Sprintf(`{"n":%d}`, i))
+		assert.Equal(t, record.Messages[0].Events[0].Type, "wasm")
+	}
+
+	// block time of the two txs at height 5 is requested once
+	assert.Equal(t, stub.headers, 2)
+}
+
+func TestClient_DecodeTx_FailedTx(t *testing.T) {
+	stub := newStubRPC(t, 1)
+	c := newStubClient(t, stub)
+	withContractTxs(t, stub, c, sdktypes.AccAddress("sender______________"))
+	stub.txs[0].TxResult.Code = 5
+	stub.txs[0].TxResult.Log = "insufficient funds"
+
+	record, err := c.DecodeTx(stub.txs[0])
+	assert.NilError(t, err)
+	assert.Equal(t, record.Code, uint32(5))
+	assert.Equal(t, record.Log, "insufficient funds")
+	assert.Equal(t, len(record.Messages[0].Events), 0)
+
+	stub.txs[0].Tx = []byte("invalid")
+	_, err = c.DecodeTx(stub.txs[0])
+	assert.ErrorContains(t, err, "TxDecoder")
+}
+
+func TestClient_HandleTxsByHeight_HashCase(t *testing.T) {
+	stub := newStubRPC(t, 1)
+	stub.txs[0].Hash = []byte{0xab, 0xcd}
+	c := newStubClient(t, stub)
+
+	var hash string
+	err := c.HandleTxsByHeight(context.Background(), testContractAddress, 1, 1, func(_ context.Context, events []abci.Event) error {
+		for _, e := range events {
+			if e.Type == "tx" && string(e.Attributes[0].Key) == "hash" {
+				hash = string(e.Attributes[0].Value)
+			}
+		}
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, hash, "abcd")
+}
@@ -0,0 +1,568 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/tendermint/tendermint/libs/bytes"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// BalanceRoundRobin spreads queries evenly between healthy endpoints
+	BalanceRoundRobin BalancingStrategy = "round-robin"
+	// BalanceLeastLatency sends queries to the healthy endpoint with the lowest status latency
+	BalanceLeastLatency BalancingStrategy = "least-latency"
+
+	// DefaultHealthCheckInterval is the default interval between endpoint health checks
+	DefaultHealthCheckInterval = 30 * time.Second
+	// DefaultHealthCheckTimeout is the default timeout of a single endpoint status request
+	DefaultHealthCheckTimeout = 5 * time.Second
+	// DefaultMaxBlockLag is the default amount of blocks an endpoint may lag behind the highest endpoint
+	DefaultMaxBlockLag int64 = 5
+
+	// stickyTxTTL is how long tx lookups are routed to the endpoint which accepted the tx
+	stickyTxTTL = 10 * time.Minute
+
+	broadcastTxMethod = "/cosmos.tx.v1beta1.Service/BroadcastTx"
+)
+
+type (
+	// Endpoint is a pair of gRPC and RPC addresses of a single node
+	Endpoint struct {
+		GRPCHost string
+		RPCHost  string
+		// Auth of the endpoint, Config.Auth is used when nil
+		Auth *AuthConfig
+	}
+
+	// BalancingStrategy selects endpoint for queries
+	BalancingStrategy string
+
+	// HealthCheckConfig configures health checks of endpoints. An endpoint is unhealthy when its status request fails,
+	// it is catching up or it lags behind the highest endpoint by more than MaxBlockLag blocks
+	HealthCheckConfig struct {
+		// Interval between checks, DefaultHealthCheckInterval by default
+		Interval time.Duration
+		// Timeout of a status request, DefaultHealthCheckTimeout by default
+		Timeout time.Duration
+		// MaxBlockLag is the allowed lag in blocks, DefaultMaxBlockLag by default
+		MaxBlockLag int64
+	}
+)
+
+func (s BalancingStrategy) validate() error {
+	switch s {
+	case "", BalanceRoundRobin, BalanceLeastLatency:
+		return nil
+	default:
+		return fmt.Errorf("unknown balancing strategy %s", s)
+	}
+}
+
+func (h HealthCheckConfig) withDefaults() HealthCheckConfig {
+	if h.Interval == 0 {
+		h.Interval = DefaultHealthCheckInterval
+	}
+	if h.Timeout == 0 {
+		h.Timeout = DefaultHealthCheckTimeout
+	}
+	if h.MaxBlockLag == 0 {
+		h.MaxBlockLag = DefaultMaxBlockLag
+	}
+
+	return h
+}
+
+// endpoint is a connected node
+type endpoint struct {
+	cfg  Endpoint
+	conn grpc.ClientConnInterface
+	rpc  rpcclient.Client
+	// intercept retries, rate limits and observes RPC requests like interceptors of the gRPC connection, nil when not intercepted
+	intercept grpc.UnaryClientInterceptor
+
+	mu      sync.Mutex
+	healthy bool
+	height  int64
+	latency time.Duration
+}
+
+func newEndpoint(cfg Endpoint, conn grpc.ClientConnInterface, rpc rpcclient.Client) *endpoint {
+	return &endpoint{cfg: cfg, conn: conn, rpc: rpc, healthy: true}
+}
+
+// callRPC calls the RPC method of the endpoint through its interceptor
+func (e *endpoint) callRPC(ctx context.Context, method string, call endpointCall) error {
+	if e.intercept == nil {
+		return call(ctx, e)
+	}
+
+	return e.intercept(ctx, method, nil, nil, nil, func(ctx context.Context, _ string, _, _ any, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		return call(ctx, e)
+	})
+}
+
+func (e *endpoint) isHealthy() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.healthy
+}
+
+func (e *endpoint) setHealthy(healthy bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.healthy = healthy
+}
+
+func (e *endpoint) getLatency() time.Duration {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.latency
+}
+
+// endpointCall is a request to the endpoint
+type endpointCall func(ctx context.Context, e *endpoint) error
+
+type stickyTx struct {
+	endpoint *endpoint
+	expires  time.Time
+}
+
+// endpointPool balances requests between endpoints and fails over to the next endpoint when one is down, retries are made
+// by interceptors of the endpoints. It serves gRPC clients as grpc.ClientConnInterface and RPC requests through poolRPC
+type endpointPool struct {
+	endpoints   []*endpoint
+	balancing   BalancingStrategy
+	health      HealthCheckConfig
+	callTimeout time.Duration
+	// chainID endpoints have to be on, not checked when empty
+	chainID string
+	logger  *slog.Logger
+
+	// ctx is cancelled when the pool is closed
+	ctx    context.Context
+	cancel context.CancelFunc
+	closed atomic.Bool
+
+	next      atomic.Uint64
+	checking  atomic.Bool
+	checkedAt atomic.Int64
+
+	stickyMu sync.Mutex
+	sticky   map[string]stickyTx
+}
+
+// newEndpointPool creates pool of the endpoints with balancing, health check and call timeout settings of the config
+func newEndpointPool(endpoints []*endpoint, cfg Config, logger *slog.Logger) *endpointPool {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &endpointPool{
+		endpoints:   endpoints,
+		balancing:   cfg.Balancing,
+		health:      cfg.HealthCheck.withDefaults(),
+		callTimeout: cfg.CallTimeout,
+		ctx:         ctx,
+		cancel:      cancel,
+		sticky:      make(map[string]stickyTx),
+		logger:      logger,
+	}
+	if !cfg.SkipChainIDCheck {
+		p.chainID = string(cfg.ChainID)
+	}
+
+	return p
+}
+
+// do calls the endpoints until the call succeeds within the call timeout
+func (p *endpointPool) do(ctx context.Context, stickyHash string, call endpointCall) error {
+	if p.callTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.callTimeout)
+		defer cancel()
+	}
+
+	return p.failover(ctx, stickyHash, call)
+}
+
+// failover calls the endpoints one by one until the call succeeds or fails with an error, which is not caused by the endpoint.
+// The endpoint which accepted tx with the sticky hash is called first. Only the last endpoint is retried by its interceptor
+func (p *endpointPool) failover(ctx context.Context, stickyHash string, call endpointCall) (err error) {
+	if p.closed.Load() {
+		return ErrClientClosed
+	}
+	tried := make(map[*endpoint]bool, len(p.endpoints))
+
+	var e *endpoint
+	if stickyHash != "" {
+		e = p.stickyEndpoint(stickyHash)
+	}
+	for {
+		if e == nil {
+			e = p.pick(tried)
+			if e == nil {
+				return err
+			}
+		}
+		tried[e] = true
+
+		callCtx := ctx
+		if len(tried) < len(p.endpoints) {
+			callCtx = withoutRetry(ctx)
+		}
+		err = call(callCtx, e)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		failover, unhealthy := classifyEndpointErr(err)
+		if !failover {
+			return err
+		}
+		if unhealthy && len(p.endpoints) > 1 {
+			e.setHealthy(false)
+		}
+		p.logger.WarnContext(ctx, "endpoint request failed, failing over", "grpc_host", e.cfg.GRPCHost, "rpc_host", e.cfg.RPCHost,
+			"unhealthy", unhealthy, "error", err)
+		e = nil
+	}
+}
+
+// pick returns the next healthy endpoint, which was not tried yet. Unhealthy endpoints are picked when no healthy one is left
+func (p *endpointPool) pick(tried map[*endpoint]bool) *endpoint {
+	p.maybeCheckHealth()
+
+	var healthy, unhealthy []*endpoint
+	for _, e := range p.endpoints {
+		if tried[e] {
+			continue
+		}
+		if e.isHealthy() {
+			healthy = append(healthy, e)
+		} else {
+			unhealthy = append(unhealthy, e)
+		}
+	}
+
+	candidates := healthy
+	if len(candidates) == 0 {
+		candidates = unhealthy
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	if p.balancing == BalanceLeastLatency {
+		best := candidates[0]
+		for _, e := range candidates[1:] {
+			if e.getLatency() < best.getLatency() {
+				best = e
+			}
+		}
+
+		return best
+	}
+
+	return candidates[(p.next.Add(1)-1)%uint64(len(candidates))]
+}
+
+// maybeCheckHealth starts background health check when the last one is older than the interval
+func (p *endpointPool) maybeCheckHealth() {
+	if len(p.endpoints) < 2 || p.closed.Load() {
+		return
+	}
+	if time.Since(time.Unix(0, p.checkedAt.Load())) < p.health.Interval {
+		return
+	}
+	if !p.checking.CompareAndSwap(false, true) {
+		return
+	}
+
+	go func() {
+		defer p.checking.Store(false)
+		p.checkHealth(p.ctx)
+	}()
+}
+
+// endpointStatus is the result of endpoint status request
+type endpointStatus struct {
+	status  *coretypes.ResultStatus
+	err     error
+	latency time.Duration
+}
+
+// checkHealth requests status of every endpoint and updates their health, heights and latencies.
+// Endpoints, which are on another network than the chain ID, are unhealthy
+func (p *endpointPool) checkHealth(ctx context.Context) []endpointStatus {
+	var wg sync.WaitGroup
+	ok := make([]bool, len(p.endpoints))
+	statuses := make([]endpointStatus, len(p.endpoints))
+	for i, e := range p.endpoints {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, p.health.Timeout)
+			defer cancel()
+
+			start := time.Now()
+			st, err := e.rpc.Status(ctx)
+			latency := time.Since(start)
+			statuses[i] = endpointStatus{status: st, err: err, latency: latency}
+
+			e.mu.Lock()
+			defer e.mu.Unlock()
+			if e.latency == 0 {
+				e.latency = latency
+			} else {
+				e.latency = (e.latency*7 + latency) / 8
+			}
+			if err != nil || st.SyncInfo.CatchingUp || (p.chainID != "" && st.NodeInfo.Network != p.chainID) {
+				return
+			}
+			e.height = st.SyncInfo.LatestBlockHeight
+			ok[i] = true
+		}()
+	}
+	wg.Wait()
+
+	var maxHeight int64
+	for _, e := range p.endpoints {
+		e.mu.Lock()
+		maxHeight = max(maxHeight, e.height)
+		e.mu.Unlock()
+	}
+	for i, e := range p.endpoints {
+		e.mu.Lock()
+		healthy := ok[i] && maxHeight-e.height <= p.health.MaxBlockLag
+		changed := healthy != e.healthy
+		e.healthy = healthy
+		e.mu.Unlock()
+
+		if changed {
+			p.logger.InfoContext(ctx, "endpoint health changed", "grpc_host", e.cfg.GRPCHost, "rpc_host", e.cfg.RPCHost, "healthy", healthy,
+				"height", e.height, "max_height", maxHeight, "error", statuses[i].err)
+		}
+	}
+
+	p.checkedAt.Store(time.Now().UnixNano())
+
+	return statuses
+}
+
+// stick routes lookups of the tx to the endpoint, which accepted it
+func (p *endpointPool) stick(txHash string, e *endpoint) {
+	if len(p.endpoints) < 2 || txHash == "" {
+		return
+	}
+
+	p.stickyMu.Lock()
+	defer p.stickyMu.Unlock()
+
+	now := time.Now()
+	for hash, s := range p.sticky {
+		if now.After(s.expires) {
+			delete(p.sticky, hash)
+		}
+	}
+	p.sticky[strings.ToUpper(txHash)] = stickyTx{endpoint: e, expires: now.Add(stickyTxTTL)}
+}
+
+func (p *endpointPool) stickyEndpoint(txHash string) *endpoint {
+	p.stickyMu.Lock()
+	defer p.stickyMu.Unlock()
+
+	s, ok := p.sticky[strings.ToUpper(txHash)]
+	if !ok || time.Now().After(s.expires) {
+		return nil
+	}
+
+	return s.endpoint
+}
+
+// Invoke implements grpc.ClientConnInterface
+func (p *endpointPool) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	if method == broadcastTxMethod {
+		// broadcasts are failed over, but not retried with backoff, as the tx may be already accepted by the node
+		return p.failover(ctx, "", func(ctx context.Context, e *endpoint) error {
+			err := e.conn.Invoke(ctx, method, args, reply, opts...)
+			if resp, ok := reply.(*txtypes.BroadcastTxResponse); ok && err == nil && resp.GetTxResponse() != nil {
+				p.stick(resp.GetTxResponse().TxHash, e)
+			}
+
+			return err
+		})
+	}
+
+	var stickyHash string
+	if req, ok := args.(*txtypes.GetTxRequest); ok {
+		stickyHash = req.Hash
+	}
+
+	return p.do(ctx, stickyHash, func(ctx context.Context, e *endpoint) error {
+		return e.conn.Invoke(ctx, method, args, reply, opts...)
+	})
+}
+
+// NewStream implements grpc.ClientConnInterface, streams are not failed over
+func (p *endpointPool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	if p.closed.Load() {
+		return nil, ErrClientClosed
+	}
+	e := p.pick(nil)
+	if e == nil {
+		return nil, errors.New("no endpoints")
+	}
+
+	return e.conn.NewStream(ctx, desc, method, opts...)
+}
+
+// close closes connections of the endpoints and stops health checks, requests fail with ErrClientClosed afterwards
+func (p *endpointPool) close() error {
+	if p.closed.Swap(true) {
+		return nil
+	}
+	p.cancel()
+	p.logger.Debug("client closed")
+
+	var errs []error
+	for _, e := range p.endpoints {
+		if closer, ok := e.conn.(interface{ Close() error }); ok {
+			errs = append(errs, closer.Close())
+		}
+		if closer, ok := e.rpc.(interface{ Close() error }); ok {
+			errs = append(errs, closer.Close())
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// classifyEndpointErr reports whether the request should be repeated on another endpoint and whether the endpoint is unhealthy
+func classifyEndpointErr(err error) (failover, unhealthy bool) {
+	var rpcErr *rpctypes.RPCError
+	if errors.As(err, &rpcErr) {
+		// the node handled the request
+		return false, false
+	}
+	if errors.Is(err, errAttemptTimeout) || errors.Is(err, errRateLimited) {
+		return true, false
+	}
+	var httpErr *httpStatusError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests {
+		return true, false
+	}
+
+	if st, ok := status.FromError(err); ok {
+		switch st.Code() {
+		case codes.Unavailable:
+			return true, true
+		case codes.ResourceExhausted:
+			return true, false
+		default:
+			return false, false
+		}
+	}
+
+	return true, true
+}
+
+// poolRPC is RPC client failing over between endpoints. Methods used by the SDK are balanced,
+// other methods, e.g. subscriptions, are served by the first endpoint
+type poolRPC struct {
+	rpcclient.Client
+	pool *endpointPool
+}
+
+func newPoolRPC(pool *endpointPool) *poolRPC {
+	return &poolRPC{Client: pool.endpoints[0].rpc, pool: pool}
+}
+
+// do calls the JSON-RPC method on the endpoints through their interceptors
+func (r *poolRPC) do(ctx context.Context, method, stickyHash string, call endpointCall) error {
+	return r.pool.do(ctx, stickyHash, func(ctx context.Context, e *endpoint) error {
+		return e.callRPC(ctx, method, call)
+	})
+}
+
+func (r *poolRPC) Status(ctx context.Context) (res *coretypes.ResultStatus, err error) {
+	err = r.do(ctx, "status", "", func(ctx context.Context, e *endpoint) error {
+		res, err = e.rpc.Status(ctx)
+		return err
+	})
+
+	return res, err
+}
+
+func (r *poolRPC) ABCIQuery(ctx context.Context, path string, data bytes.HexBytes) (res *coretypes.ResultABCIQuery, err error) {
+	return r.ABCIQueryWithOptions(ctx, path, data, rpcclient.DefaultABCIQueryOptions)
+}
+
+func (r *poolRPC) ABCIQueryWithOptions(ctx context.Context, path string, data bytes.HexBytes, opts rpcclient.ABCIQueryOptions) (res *coretypes.ResultABCIQuery, err error) {
+	err = r.do(ctx, "abci_query", "", func(ctx context.Context, e *endpoint) error {
+		res, err = e.rpc.ABCIQueryWithOptions(ctx, path, data, opts)
+		return err
+	})
+
+	return res, err
+}
+
+func (r *poolRPC) Tx(ctx context.Context, hash bytes.HexBytes, prove bool) (res *coretypes.ResultTx, err error) {
+	err = r.do(ctx, "tx", hash.String(), func(ctx context.Context, e *endpoint) error {
+		res, err = e.rpc.Tx(ctx, hash, prove)
+		return err
+	})
+
+	return res, err
+}
+
+func (r *poolRPC) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (res *coretypes.ResultTxSearch, err error) {
+	err = r.do(ctx, "tx_search", "", func(ctx context.Context, e *endpoint) error {
+		res, err = e.rpc.TxSearch(ctx, query, prove, page, perPage, orderBy)
+		return err
+	})
+
+	return res, err
+}
+
+func (r *poolRPC) Header(ctx context.Context, height *int64) (res *coretypes.ResultHeader, err error) {
+	err = r.do(ctx, "header", "", func(ctx context.Context, e *endpoint) error {
+		res, err = e.rpc.Header(ctx, height)
+		return err
+	})
+
+	return res, err
+}
+
+func (r *poolRPC) Block(ctx context.Context, height *int64) (res *coretypes.ResultBlock, err error) {
+	err = r.do(ctx, "block", "", func(ctx context.Context, e *endpoint) error {
+		res, err = e.rpc.Block(ctx, height)
+		return err
+	})
+
+	return res, err
+}
+
+func (r *poolRPC) BlockResults(ctx context.Context, height *int64) (res *coretypes.ResultBlockResults, err error) {
+	err = r.do(ctx, "block_results", "", func(ctx context.Context, e *endpoint) error {
+		res, err = e.rpc.BlockResults(ctx, height)
+		return err
+	})
+
+	return res, err
+}
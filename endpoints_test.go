@@ -0,0 +1,210 @@
+package sdk
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/tendermint/tendermint/rpc/client/http"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+// fakeConn is a gRPC connection answering tx service requests
+type fakeConn struct {
+	mu          sync.Mutex
+	unavailable bool
+	// failures is the amount of upcoming calls failed with ResourceExhausted
+	failures int
+	// hangs is the amount of upcoming calls blocked until the context is done
+	hangs int
+	calls []string
+}
+
+func (f *fakeConn) Invoke(ctx context.Context, method string, args, reply any, _ ...grpc.CallOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.calls = append(f.calls, method)
+	if f.unavailable {
+		return status.Error(codes.Unavailable, "connection refused")
+	}
+	if f.failures > 0 {
+		f.failures--
+		return status.Error(codes.ResourceExhausted, "too many requests")
+	}
+	if f.hangs > 0 {
+		f.hangs--
+		f.mu.Unlock()
+		<-ctx.Done()
+		f.mu.Lock()
+
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	switch reply := reply.(type) {
+	case *txtypes.BroadcastTxResponse:
+		reply.TxResponse = &sdktypes.TxResponse{TxHash: "ABCDEF"}
+	case *txtypes.GetTxResponse:
+		reply.TxResponse = &sdktypes.TxResponse{TxHash: args.(*txtypes.GetTxRequest).Hash}
+	}
+
+	return nil
+}
+
+func (f *fakeConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, status.Error(codes.Unimplemented, "streams")
+}
+
+func (f *fakeConn) callCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.calls)
+}
+
+// interceptedConn calls the fake connection through the interceptor, as gRPC does for dialed connections
+type interceptedConn struct {
+	*fakeConn
+	intercept grpc.UnaryClientInterceptor
+}
+
+func (c interceptedConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	return c.intercept(ctx, method, args, reply, nil, func(ctx context.Context, method string, args, reply any, _ *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return c.fakeConn.Invoke(ctx, method, args, reply, opts...)
+	}, opts...)
+}
+
+// newFakeConnPool creates pool of endpoints with fake gRPC connections intercepted as in NewClient, their health is not checked during the test
+func newFakeConnPool(cfg Config, conns ...*fakeConn) *endpointPool {
+	var endpoints []*endpoint
+	for _, conn := range conns {
+		endpoints = append(endpoints, newEndpoint(Endpoint{}, interceptedConn{fakeConn: conn, intercept: endpointInterceptor(cfg, newLogger(cfg))}, nil))
+	}
+	pool := newEndpointPool(endpoints, cfg, newLogger(Config{}))
+	pool.checkedAt.Store(time.Now().UnixNano())
+
+	return pool
+}
+
+func TestEndpointPool_GRPCFailover(t *testing.T) {
+	up, down := &fakeConn{}, &fakeConn{unavailable: true}
+	pool := newFakeConnPool(Config{}, down, up)
+	txClient := txtypes.NewServiceClient(pool)
+
+	for range 4 {
+		_, err := txClient.GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, up.callCount(), 4)
+	// the failed endpoint is skipped until the next health check
+	assert.Equal(t, down.callCount(), 1)
+	assert.Assert(t, !pool.endpoints[0].isHealthy())
+
+	down.mu.Lock()
+	down.unavailable = false
+	down.mu.Unlock()
+	up.mu.Lock()
+	up.unavailable = true
+	up.mu.Unlock()
+
+	// unhealthy endpoints are used when no healthy endpoint is left
+	_, err := txClient.GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.NilError(t, err)
+	assert.Equal(t, down.callCount(), 2)
+}
+
+func TestEndpointPool_StickyTx(t *testing.T) {
+	conns := []*fakeConn{{}, {}, {}}
+	pool := newFakeConnPool(Config{}, conns...)
+	txClient := txtypes.NewServiceClient(pool)
+	ctx := context.Background()
+
+	resp, err := txClient.BroadcastTx(ctx, &txtypes.BroadcastTxRequest{})
+	assert.NilError(t, err)
+	assert.Equal(t, resp.TxResponse.TxHash, "ABCDEF")
+	assert.Equal(t, conns[0].callCount(), 1)
+
+	for range 3 {
+		_, err = txClient.GetTx(ctx, &txtypes.GetTxRequest{Hash: "abcdef"})
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, conns[0].callCount(), 4)
+
+	// other lookups are balanced
+	for range 2 {
+		_, err = txClient.GetTx(ctx, &txtypes.GetTxRequest{Hash: "012345"})
+		assert.NilError(t, err)
+	}
+	assert.Equal(t, conns[1].callCount()+conns[2].callCount(), 2)
+}
+
+func TestEndpointPool_RPCFailover(t *testing.T) {
+	down, up := newStubRPC(t, 1), newStubRPC(t, 1)
+	down.server.Close()
+
+	var endpoints []*endpoint
+	for _, s := range []*stubRPC{down, up} {
+		rpc, err := http.New(s.server.URL)
+		assert.NilError(t, err)
+		endpoints = append(endpoints, newEndpoint(Endpoint{RPCHost: s.server.URL}, nil, rpc))
+	}
+	pool := newEndpointPool(endpoints, Config{}, newLogger(Config{}))
+	pool.checkedAt.Store(time.Now().UnixNano())
+	node := newPoolRPC(pool)
+
+	for range 3 {
+		page, perPage := 1, 10
+		res, err := node.TxSearch(context.Background(), "tx.height>=1 AND tx.height<=1", false, &page, &perPage, "asc")
+		assert.NilError(t, err)
+		assert.Equal(t, res.TotalCount, 1)
+	}
+	assert.Equal(t, up.searchCount(), 3)
+	assert.Assert(t, !pool.endpoints[0].isHealthy())
+}
+
+func TestEndpointPool_HealthCheck(t *testing.T) {
+	synced, behind, lagging, catchingUp := newStubRPC(t), newStubRPC(t), newStubRPC(t), newStubRPC(t)
+	synced.height, behind.height, lagging.height, catchingUp.height = 100, 97, 90, 100
+	catchingUp.catchingUp = true
+
+	var endpoints []*endpoint
+	for _, s := range []*stubRPC{synced, behind, lagging, catchingUp} {
+		rpc, err := http.New(s.server.URL)
+		assert.NilError(t, err)
+		endpoints = append(endpoints, newEndpoint(Endpoint{RPCHost: s.server.URL}, nil, rpc))
+	}
+	pool := newEndpointPool(endpoints, Config{Balancing: BalanceLeastLatency, HealthCheck: HealthCheckConfig{MaxBlockLag: 5}}, newLogger(Config{}))
+
+	pool.checkHealth(context.Background())
+	var healthy []bool
+	for _, e := range pool.endpoints {
+		healthy = append(healthy, e.isHealthy())
+		assert.Assert(t, e.getLatency() > 0)
+	}
+	assert.DeepEqual(t, healthy, []bool{true, true, false, false})
+
+	picked := pool.pick(nil)
+	assert.Assert(t, picked == pool.endpoints[0] || picked == pool.endpoints[1])
+}
+
+func TestConfig_ValidateEndpoints(t *testing.T) {
+	cfg := Config{ChainID: testChainID, Endpoints: []Endpoint{{GRPCHost: "localhost:9090", RPCHost: "http://localhost:26657"}}}
+	assert.NilError(t, cfg.Validate())
+	assert.Equal(t, len(cfg.endpoints()), 1)
+
+	cfg.GRPCHost = "localhost:9091"
+	assert.ErrorContains(t, cfg.Validate(), "endpoint 0: empty RPCHost")
+
+	cfg.RPCHost = "http://localhost:26658"
+	assert.NilError(t, cfg.Validate())
+	assert.Equal(t, len(cfg.endpoints()), 2)
+
+	cfg.Balancing = "random"
+	assert.ErrorContains(t, cfg.Validate(), "unknown balancing strategy")
+}
@@ -0,0 +1,60 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// EVMAddress returns checksummed EVM address of the secp256k1 public key, the last 20 bytes of keccak256 of the uncompressed key.
+// It differs from the sei address of the same key, which is derived from the compressed key
+func EVMAddress(pubKey cryptotypes.PubKey) (string, error) {
+	secpKey, ok := pubKey.(*secp256k1.PubKey)
+	if !ok {
+		return "", fmt.Errorf("%T is not a secp256k1 public key", pubKey)
+	}
+
+	ecdsaKey, err := ethcrypto.DecompressPubkey(secpKey.Key)
+	if err != nil {
+		return "", fmt.Errorf("DecompressPubkey: %w", err)
+	}
+
+	return ethcrypto.PubkeyToAddress(*ecdsaKey).Hex(), nil
+}
+
+// SeiAddress returns bech32 account address of the public key with the prefix of the clients
+func SeiAddress(pubKey cryptotypes.PubKey) (string, error) {
+	return bech32.ConvertAndEncode(accountPrefix(), pubKey.Address())
+}
+
+// ConvertAddrToHex returns bytes of the bech32 address as 0x-prefixed hex. Note that the result is the EVM address
+// of the account only for accounts created from EVM, which are not associated with a public key yet.
+// Use EVMAddress to derive the EVM address of a public key
+func ConvertAddrToHex(address string) (string, error) {
+	_, addrBytes, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		return "", err
+	}
+
+	return ethcommon.BytesToAddress(addrBytes).Hex(), nil
+}
+
+// ConvertHexToAddr returns bech32 address with the hrp of the 0x-prefixed hex address bytes. As with ConvertAddrToHex,
+// the result is the sei address of the EVM account only until the account is associated with a public key
+func ConvertHexToAddr(hexAddress, hrp string) (string, error) {
+	addrBytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(hexAddress, "0x"), "0X"))
+	if err != nil {
+		return "", fmt.Errorf("DecodeString: %w", err)
+	}
+	if len(addrBytes) != ethcommon.AddressLength {
+		return "", fmt.Errorf("invalid address length %d, expected %d", len(addrBytes), ethcommon.AddressLength)
+	}
+
+	return bech32.ConvertAndEncode(hrp, addrBytes)
+}
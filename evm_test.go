@@ -0,0 +1,50 @@
+package sdk
+
+import (
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/ed25519"
+	"gotest.tools/assert"
+)
+
+// testEVMAddress is the EVM address of testPrivKeyHex
+const testEVMAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+func TestClient_SignerEVMAddress(t *testing.T) {
+	c := newKeyringClient()
+
+	addr, err := c.AddSignerFromPrivKey("evm", testPrivKeyHex)
+	assert.NilError(t, err)
+
+	info, err := c.GetSigner("evm")
+	assert.NilError(t, err)
+	assert.DeepEqual(t, info, SignerInfo{Name: "evm", Address: addr, EVMAddress: testEVMAddress})
+
+	found, err := c.GetSignerByEVMAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	assert.NilError(t, err)
+	assert.Equal(t, found.Name, "evm")
+	_, err = c.GetSignerByEVMAddress("0x0000000000000000000000000000000000000000")
+	assert.ErrorContains(t, err, "not added")
+
+	pubKey, err := c.GetSignerPubKey("evm")
+	assert.NilError(t, err)
+	evmAddr, err := EVMAddress(pubKey)
+	assert.NilError(t, err)
+	assert.Equal(t, evmAddr, testEVMAddress)
+
+	seiAddr, err := SeiAddress(pubKey)
+	assert.NilError(t, err)
+	hexAddr, err := ConvertAddrToHex(seiAddr)
+	assert.NilError(t, err)
+	// the sei and EVM addresses of a key are derived differently
+	assert.Assert(t, hexAddr != testEVMAddress)
+	back, err := ConvertHexToAddr(hexAddr, Bech32PrefixAccAddr)
+	assert.NilError(t, err)
+	assert.Equal(t, back, seiAddr)
+
+	_, err = ConvertHexToAddr("0xabcd", Bech32PrefixAccAddr)
+	assert.ErrorContains(t, err, "invalid address length")
+
+	_, err = EVMAddress(ed25519.GenPrivKey().PubKey())
+	assert.ErrorContains(t, err, "not a secp256k1 public key")
+}
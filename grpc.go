@@ -1,9 +1,7 @@
 package sdk
 
 import (
-	"context"
 	"crypto/tls"
-	"encoding/base64"
 	"fmt"
 	"net/url"
 	"strings"
@@ -13,29 +11,12 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-// basicAuth is a simple implementation of credentials.PerRPCCredentials
-// to support basic authentication for grpc requests
-// customers may copy/paste this or implement with their own struct
-type basicAuth struct {
-	username string
-	password string
-}
-
-func (b basicAuth) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
-	auth := b.username + ":" + b.password
-	enc := base64.StdEncoding.EncodeToString([]byte(auth))
-	return map[string]string{
-		"authorization": "Basic " + enc,
-	}, nil
-}
-
-func (basicAuth) RequireTransportSecurity() bool {
-	return false
-}
-
-func getGRPCConn(cfg Config) (*grpc.ClientConn, error) {
-	grpcHost := cfg.GRPCHost
-	grpcDialOptions := []grpc.DialOption{grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024 * 1024 * 1024))}
+// getGRPCConn creates gRPC connection to the endpoint with auth of the endpoint or the config.
+// UseBasicAuth is the legacy mode taking basic auth credentials from the gRPC host URL
+func getGRPCConn(cfg Config, e Endpoint, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	grpcHost := e.GRPCHost
+	auth := cfg.auth(e)
+	grpcDialOptions := append([]grpc.DialOption{grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(1024 * 1024 * 1024))}, opts...)
 
 	if cfg.UseBasicAuth { //nolint:gocritic
 		parsed, err := url.Parse(grpcHost)
@@ -53,13 +34,24 @@ func getGRPCConn(cfg Config) (*grpc.ClientConn, error) {
 		grpcDialOptions = append(grpcDialOptions, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{}))) //nolint:gosec
 		grpcHost = fmt.Sprintf("%s:9090", parsed.Hostname())
 		if password != "" {
-			// create rpc credentials using basicAuth implementation of credentials.PerRPCCredentials
-			grpcDialOptions = append(grpcDialOptions, grpc.WithPerRPCCredentials(basicAuth{username: username, password: password}))
+			basicAuth := &AuthConfig{Username: username, Password: password}
+			grpcDialOptions = append(grpcDialOptions, grpc.WithPerRPCCredentials(headerCredentials{headers: basicAuth.headers()}))
 		}
 	} else if cfg.InsecureGRPC {
 		grpcDialOptions = append(grpcDialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	} else {
-		grpcDialOptions = append(grpcDialOptions, grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")))
+		tlsConfig, err := auth.tlsConfig()
+		if err != nil {
+			return nil, fmt.Errorf("tlsConfig: %w", err)
+		}
+		if tlsConfig != nil {
+			grpcDialOptions = append(grpcDialOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+		} else {
+			grpcDialOptions = append(grpcDialOptions, grpc.WithTransportCredentials(credentials.NewClientTLSFromCert(nil, "")))
+		}
+	}
+	if headers := auth.headers(); len(headers) > 0 {
+		grpcDialOptions = append(grpcDialOptions, grpc.WithPerRPCCredentials(headerCredentials{headers: headers}))
 	}
 
 	conn, err := grpc.NewClient(grpcHost, grpcDialOptions...)
@@ -0,0 +1,163 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+const defaultIndexerPollInterval = 5 * time.Second
+
+// IndexerConfig configures Indexer
+type IndexerConfig struct {
+	// Scan configures the underlying scanner. HeightFrom is used only when the sink is empty,
+	// HeightTo 0 means the latest height at the moment of every scan round
+	Scan ScanConfig
+	// EventTypes restricts indexed events by type. Empty means every event
+	EventTypes []string
+
+	// Follow keeps indexing new blocks after the range is done. It is ignored when Scan.HeightTo is set
+	Follow bool
+	// PollInterval is the delay between scan rounds in follow mode
+	PollInterval time.Duration
+}
+
+// Indexer wires the tx scanner to a sink, resuming from the sink checkpoint
+type Indexer struct {
+	client *Client
+	sink   Sink
+	cfg    IndexerConfig
+}
+
+// NewIndexer creates indexer writing txs found by the client to the sink
+func NewIndexer(client *Client, sink Sink, cfg IndexerConfig) *Indexer {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultIndexerPollInterval
+	}
+
+	return &Indexer{
+		client: client,
+		sink:   sink,
+		cfg:    cfg,
+	}
+}
+
+// Run indexes the configured range. In follow mode it runs until ctx is done
+func (i *Indexer) Run(ctx context.Context) error {
+	if i.client == nil {
+		return errors.New("nil client")
+	}
+	if i.sink == nil {
+		return errors.New("nil sink")
+	}
+
+	for {
+		last, err := i.sink.LastHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("sink.LastHeight: %w", err)
+		}
+
+		from := max(i.cfg.Scan.HeightFrom, last+1, 1)
+		to := i.cfg.Scan.HeightTo
+		if to == 0 {
+			to, err = i.client.GetLatestHeight(ctx)
+			if err != nil {
+				return fmt.Errorf("GetLatestHeight: %w", err)
+			}
+		}
+
+		if from <= to {
+			err = i.index(ctx, from, to)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !i.cfg.Follow || i.cfg.Scan.HeightTo != 0 {
+			return nil
+		}
+
+		select {
+		case <-time.After(i.cfg.PollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// index scans [from, to] writing a block per height with events and a checkpoint after every shard
+func (i *Indexer) index(ctx context.Context, from, to int64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		batch       []IndexedEvent
+		batchHeight int64
+		lastWritten = from - 1
+		sinkErr     error
+	)
+
+	flush := func() error {
+		if batchHeight == 0 {
+			return nil
+		}
+
+		err := i.sink.WriteBlock(ctx, batchHeight, batch)
+		if err != nil {
+			return fmt.Errorf("sink.WriteBlock %d: %w", batchHeight, err)
+		}
+		lastWritten = batchHeight
+		batch, batchHeight = nil, 0
+
+		return nil
+	}
+
+	scanCfg := i.cfg.Scan
+	scanCfg.HeightFrom, scanCfg.HeightTo = from, to
+	scanCfg.OnProgress = func(p ScanProgress) {
+		if sinkErr != nil {
+			return
+		}
+
+		// every tx of the shard is delivered at this point
+		sinkErr = flush()
+		if sinkErr == nil && p.Height > lastWritten {
+			sinkErr = i.sink.WriteBlock(ctx, p.Height, nil)
+			lastWritten = p.Height
+		}
+		if sinkErr != nil {
+			cancel()
+			return
+		}
+
+		if i.cfg.Scan.OnProgress != nil {
+			i.cfg.Scan.OnProgress(p)
+		}
+	}
+
+	err := i.client.ScanTxs(ctx, scanCfg, func(_ context.Context, tx *coretypes.ResultTx) error {
+		// shards fetched before the sink failed are still delivered
+		if sinkErr != nil {
+			return sinkErr
+		}
+		if batchHeight != 0 && tx.Height != batchHeight {
+			err := flush()
+			if err != nil {
+				return err
+			}
+		}
+
+		batchHeight = tx.Height
+		batch = append(batch, NewIndexedEvents(tx, i.cfg.EventTypes...)...)
+
+		return nil
+	})
+	if sinkErr != nil {
+		return sinkErr
+	}
+
+	return err
+}
@@ -0,0 +1,77 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+// failingSink fails writes of blocks from failHeight and counts writes attempted after the first failure
+type failingSink struct {
+	*MemorySink
+	failHeight int64
+
+	failed             bool
+	writesAfterFailure int
+}
+
+func (s *failingSink) WriteBlock(ctx context.Context, height int64, events []IndexedEvent) error {
+	if s.failed {
+		s.writesAfterFailure++
+	}
+	if height >= s.failHeight {
+		s.failed = true
+		return errors.New("disk full")
+	}
+
+	return s.MemorySink.WriteBlock(ctx, height, events)
+}
+
+func TestIndexer_Run(t *testing.T) {
+	var heights []int64
+	for h := int64(1); h <= 20; h++ {
+		heights = append(heights, h)
+	}
+	stub := newStubRPC(t, heights...)
+	c := newStubClient(t, stub)
+	sink := NewMemorySink()
+
+	assert.NilError(t, sink.WriteBlock(context.Background(), 5, nil))
+
+	err := NewIndexer(c, sink, IndexerConfig{
+		Scan: ScanConfig{ContractAddress: testContractAddress, HeightFrom: 1, HeightTo: 20, ShardSize: 4},
+	}).Run(context.Background())
+	assert.NilError(t, err)
+
+	// indexing resumes after the checkpoint
+	events := sink.Events("wasm")
+	assert.Equal(t, len(events), 15)
+	assert.Equal(t, events[0].Height, int64(6))
+
+	last, err := sink.LastHeight(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, last, int64(20))
+}
+
+func TestIndexer_SinkError(t *testing.T) {
+	var heights []int64
+	for h := int64(1); h <= 40; h++ {
+		heights = append(heights, h)
+	}
+	stub := newStubRPC(t, heights...)
+	c := newStubClient(t, stub)
+	// height 2 is the last block of the first shard, it is written once the shard is delivered
+	sink := &failingSink{MemorySink: NewMemorySink(), failHeight: 2}
+
+	err := NewIndexer(c, sink, IndexerConfig{
+		Scan: ScanConfig{ContractAddress: testContractAddress, HeightFrom: 1, HeightTo: 40, ShardSize: 2, Workers: 8},
+	}).Run(context.Background())
+	assert.ErrorContains(t, err, "disk full")
+	assert.Equal(t, sink.writesAfterFailure, 0)
+
+	last, err := sink.LastHeight(context.Background())
+	assert.NilError(t, err)
+	assert.Equal(t, last, int64(1))
+}
@@ -0,0 +1,146 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	dkeyring "github.com/99designs/keyring"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/tendermint/crypto/bcrypt"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+)
+
+// Supported keyring backends
+const (
+	KeyringBackendMemory = keyring.BackendMemory
+	KeyringBackendFile   = keyring.BackendFile
+	KeyringBackendTest   = keyring.BackendTest
+)
+
+const (
+	// keyringAppName matches seid, so keyrings created by seid can be opened
+	keyringAppName = "sei"
+	// keyringFileDir and keyringKeyhashFile are the file backend directory and its passphrase hash, as seid names them
+	keyringFileDir     = "keyring-file"
+	keyringKeyhashFile = "keyhash"
+)
+
+// Validate validates keyring config
+func (cfg *KeyringConfig) Validate() error {
+	switch cfg.Backend {
+	case "", KeyringBackendMemory:
+	case KeyringBackendTest:
+		if cfg.Dir == "" {
+			return errors.New("empty Dir")
+		}
+	case KeyringBackendFile:
+		if cfg.Dir == "" {
+			return errors.New("empty Dir")
+		}
+		if len(cfg.Passphrase) < input.MinPassLength {
+			return fmt.Errorf("passphrase must be at least %d characters", input.MinPassLength)
+		}
+	default:
+		return fmt.Errorf("unsupported backend %s", cfg.Backend)
+	}
+
+	return nil
+}
+
+// newKeyring opens keyring of the configured backend
+func newKeyring(cfg KeyringConfig) (keyring.Keyring, error) {
+	switch cfg.Backend {
+	case "", KeyringBackendMemory:
+		return keyring.NewInMemory(), nil
+	case KeyringBackendFile:
+		return newFileKeyring(cfg.Dir, cfg.Passphrase)
+	default:
+		return keyring.New(keyringAppName, cfg.Backend, cfg.Dir, nil)
+	}
+}
+
+// newFileKeyring opens the file backend with the passphrase. The SDK file backend prompts on the terminal
+// when stdin is a TTY, so the backend is opened directly and the passphrase is checked against the keyhash as seid does
+func newFileKeyring(dir, passphrase string) (keyring.Keyring, error) {
+	fileDir := filepath.Join(dir, keyringFileDir)
+	err := os.MkdirAll(fileDir, 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("create keyring dir: %w", err)
+	}
+
+	err = checkKeyhash(fileDir, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := dkeyring.Open(dkeyring.Config{
+		AllowedBackends:  []dkeyring.BackendType{dkeyring.FileBackend},
+		ServiceName:      keyringAppName,
+		FileDir:          fileDir,
+		FilePasswordFunc: func(string) (string, error) { return passphrase, nil },
+	})
+	if err != nil {
+		return nil, fmt.Errorf("open keyring: %w", err)
+	}
+
+	// the wrapper is not specific to the memory backend, it only adapts the opened keyring
+	return keyring.NewInMemoryWithKeyring(db), nil
+}
+
+// checkKeyhash compares the passphrase with the keyhash of the file backend, creating the keyhash for a new keyring
+func checkKeyhash(dir, passphrase string) error {
+	path := filepath.Join(dir, keyringKeyhashFile)
+	keyhash, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		keyhash, err = bcrypt.GenerateFromPassword(tmcrypto.CRandBytes(16), []byte(passphrase), 2)
+		if err != nil {
+			return fmt.Errorf("hash passphrase: %w", err)
+		}
+
+		err = os.WriteFile(path, keyhash, 0o600)
+		if err != nil {
+			return fmt.Errorf("write keyhash: %w", err)
+		}
+
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read keyhash: %w", err)
+	}
+
+	err = bcrypt.CompareHashAndPassword(keyhash, []byte(passphrase))
+	if err != nil {
+		return errors.New("incorrect keyring passphrase")
+	}
+
+	return nil
+}
+
+// loadSigners registers every local key of the keyring as signer
+func (c *Client) loadSigners() error {
+	infos, err := c.clientCtx.Keyring.List()
+	if err != nil {
+		return fmt.Errorf("Keyring.List: %w", err)
+	}
+
+	for _, info := range infos {
+		if info.GetType() != keyring.TypeLocal {
+			continue
+		}
+
+		sgn, err := c.newKeyringSigner(info.GetName())
+		if err != nil {
+			return err
+		}
+
+		err = c.signers.add(sgn)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
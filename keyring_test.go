@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"gotest.tools/assert"
+)
+
+func TestKeyring_LoadSigners(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  KeyringConfig
+	}{
+		{name: "test", cfg: KeyringConfig{Backend: KeyringBackendTest, Dir: t.TempDir()}},
+		{name: "file", cfg: KeyringConfig{Backend: KeyringBackendFile, Dir: t.TempDir(), Passphrase: "12345678"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NilError(t, tt.cfg.Validate())
+
+			newClient := func() *Client {
+				kr, err := newKeyring(tt.cfg)
+				assert.NilError(t, err)
+
+				c := &Client{clientCtx: client.Context{}.WithKeyring(kr), signers: newSignerRegistry()}
+				assert.NilError(t, c.loadSigners())
+
+				return c
+			}
+
+			c := newClient()
+			assert.Equal(t, len(c.ListSigners()), 0)
+			_, err := c.AddSigner("persisted", testMnemonic1)
+			assert.NilError(t, err)
+			info, err := c.GetSigner("persisted")
+			assert.NilError(t, err)
+
+			restarted := newClient()
+			assert.DeepEqual(t, restarted.ListSigners(), []SignerInfo{info})
+		})
+	}
+}
+
+func TestKeyring_FilePassphrase(t *testing.T) {
+	dir := t.TempDir()
+	kr, err := newKeyring(KeyringConfig{Backend: KeyringBackendFile, Dir: dir, Passphrase: "12345678"})
+	assert.NilError(t, err)
+	_, err = kr.NewAccount("persisted", testMnemonic1, "", KeyOptions{}.hdPath(), hd.Secp256k1)
+	assert.NilError(t, err)
+
+	_, err = newKeyring(KeyringConfig{Backend: KeyringBackendFile, Dir: dir, Passphrase: "87654321"})
+	assert.ErrorContains(t, err, "incorrect keyring passphrase")
+
+	// seid opens the keyring with the same passphrase
+	seid, err := keyring.New(keyringAppName, keyring.BackendFile, dir, strings.NewReader("12345678\n"))
+	assert.NilError(t, err)
+	_, err = seid.Key("persisted")
+	assert.NilError(t, err)
+}
+
+func TestKeyringConfig_Validate(t *testing.T) {
+	assert.NilError(t, (&KeyringConfig{}).Validate())
+	assert.ErrorContains(t, (&KeyringConfig{Backend: KeyringBackendTest}).Validate(), "empty Dir")
+	assert.ErrorContains(t, (&KeyringConfig{Backend: KeyringBackendFile, Dir: "dir", Passphrase: "short"}).Validate(), "passphrase must be")
+	assert.ErrorContains(t, (&KeyringConfig{Backend: "os"}).Validate(), "unsupported backend")
+}
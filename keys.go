@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+const (
+	// CoinTypeCosmos is the coin type used by Cosmos wallets and by AddSigner
+	CoinTypeCosmos uint32 = 118
+	// CoinTypeEVM is the coin type used by EVM compatible Sei wallets
+	CoinTypeEVM uint32 = 60
+)
+
+// KeyOptions configures HD derivation of signer keys
+type KeyOptions struct {
+	// CoinType of the BIP44 path. 0 means CoinTypeCosmos
+	CoinType uint32
+	// Account of the BIP44 path
+	Account uint32
+	// Index is the address index of the BIP44 path
+	Index uint32
+	// Passphrase is the BIP39 passphrase, empty by default
+	Passphrase string
+}
+
+// hdPath returns BIP44 path of the options
+func (o KeyOptions) hdPath() string {
+	coinType := o.CoinType
+	if coinType == 0 {
+		coinType = CoinTypeCosmos
+	}
+
+	return hd.CreateHDPath(coinType, o.Account, o.Index).String()
+}
+
+// AddSignersFromMnemonic derives count sequential keys from the mnemonic, starting from opts.Index,
+// and adds them as signers named "<namePrefix>-<index>". Either every signer is added or none
+func (c *Client) AddSignersFromMnemonic(namePrefix, mnemonic string, count uint32, opts KeyOptions) ([]SignerInfo, error) {
+	if namePrefix == "" {
+		return nil, errors.New("empty name prefix")
+	}
+	if count == 0 {
+		return nil, errors.New("zero count")
+	}
+
+	res := make([]SignerInfo, 0, count)
+	for i := range count {
+		keyOpts := opts
+		keyOpts.Index = opts.Index + i
+		name := fmt.Sprintf("%s-%d", namePrefix, keyOpts.Index)
+
+		sgn, err := c.addMnemonicSigner(name, mnemonic, keyOpts)
+		if err != nil {
+			for _, added := range res {
+				_ = c.RemoveSigner(added.Name)
+			}
+
+			return nil, fmt.Errorf("add signer %s: %w", name, err)
+		}
+
+		res = append(res, sgn.info())
+	}
+
+	return res, nil
+}
+
+// AddSignerFromPrivKey adds signer from a hex encoded secp256k1 private key, "0x" prefix is allowed
+func (c *Client) AddSignerFromPrivKey(name, privKeyHex string) (string, error) {
+	privKeyBytes, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(privKeyHex), "0x"))
+	if err != nil {
+		return "", fmt.Errorf("DecodeString: %w", err)
+	}
+	if len(privKeyBytes) != secp256k1.PrivKeySize {
+		return "", fmt.Errorf("invalid private key length %d, expected %d", len(privKeyBytes), secp256k1.PrivKeySize)
+	}
+
+	return c.addSignerFromPrivKey(name, &secp256k1.PrivKey{Key: privKeyBytes})
+}
+
+// AddSignerFromArmor adds signer from an ASCII-armored private key encrypted with the passphrase, as exported by `seid keys export`
+func (c *Client) AddSignerFromArmor(name, armor, passphrase string) (string, error) {
+	if armor == "" {
+		return "", errors.New("empty armor")
+	}
+
+	return c.addSigner(name, func() error {
+		return c.clientCtx.Keyring.ImportPrivKey(name, armor, passphrase)
+	})
+}
+
+// AddSignerFromKeystore adds signer from an Ethereum JSON keystore (web3 secret storage) encrypted with the passphrase
+func (c *Client) AddSignerFromKeystore(name string, keystoreJSON []byte, passphrase string) (string, error) {
+	key, err := ethkeystore.DecryptKey(keystoreJSON, passphrase)
+	if err != nil {
+		return "", fmt.Errorf("DecryptKey: %w", err)
+	}
+
+	privKeyBytes := make([]byte, secp256k1.PrivKeySize)
+	key.PrivateKey.D.FillBytes(privKeyBytes)
+
+	return c.addSignerFromPrivKey(name, &secp256k1.PrivKey{Key: privKeyBytes})
+}
+
+// addSignerFromPrivKey imports the private key into the keyring through an armor, as the keyring does not accept raw keys
+func (c *Client) addSignerFromPrivKey(name string, privKey *secp256k1.PrivKey) (string, error) {
+	const importPassphrase = "import"
+
+	armor := crypto.EncryptArmorPrivKey(legacy.Cdc.MustMarshal(privKey), importPassphrase, string(hd.Secp256k1Type))
+
+	return c.addSigner(name, func() error {
+		return c.clientCtx.Keyring.ImportPrivKey(name, armor, importPassphrase)
+	})
+}
+
+// addSigner creates keyring key with the import function and registers it as signer
+func (c *Client) addSigner(name string, importKey func() error) (string, error) {
+	if name == "" {
+		return "", errors.New("empty name")
+	}
+	e, err := c.signers.reserve(name)
+	if err != nil {
+		return "", err
+	}
+
+	err = importKey()
+	if err != nil {
+		c.signers.cancel(e)
+		return "", fmt.Errorf("ImportPrivKey: %w", err)
+	}
+
+	sgn, err := c.newKeyringSigner(name)
+	if err != nil {
+		// the key was imported by this call under the reserved name
+		_ = c.clientCtx.Keyring.Delete(name)
+		c.signers.cancel(e)
+		return "", err
+	}
+	c.signers.commit(e, sgn)
+
+	return sgn.address.String(), nil
+}
@@ -0,0 +1,143 @@
+package sdk
+
+import (
+	"encoding/hex"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	ethkeystore "github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"gotest.tools/assert"
+)
+
+func TestKeyOptions_HDPath(t *testing.T) {
+	assert.Equal(t, KeyOptions{}.hdPath(), "m/44'/118'/0'/0/0")
+	assert.Equal(t, KeyOptions{CoinType: CoinTypeEVM, Account: 2, Index: 3}.hdPath(), "m/44'/60'/2'/0/3")
+}
+
+func TestClient_AddSignerWithOptions(t *testing.T) {
+	c := newKeyringClient()
+
+	defaultAddr, err := c.AddSigner("default", testMnemonic1)
+	assert.NilError(t, err)
+
+	evmAddr, err := c.AddSignerWithOptions("evm", testMnemonic1, KeyOptions{CoinType: CoinTypeEVM})
+	assert.NilError(t, err)
+	assert.Assert(t, evmAddr != defaultAddr)
+
+	passAddr, err := c.AddSignerWithOptions("pass", testMnemonic1, KeyOptions{Passphrase: "secret"})
+	assert.NilError(t, err)
+	assert.Assert(t, passAddr != defaultAddr)
+}
+
+func TestClient_AddSignersFromMnemonic(t *testing.T) {
+	c := newKeyringClient()
+
+	signers, err := c.AddSignersFromMnemonic("bot", testMnemonic1, 3, KeyOptions{Index: 1})
+	assert.NilError(t, err)
+	assert.Equal(t, len(signers), 3)
+	assert.Equal(t, signers[0].Name, "bot-1")
+	assert.Equal(t, signers[2].Name, "bot-3")
+
+	seen := make(map[string]struct{})
+	for _, s := range signers {
+		seen[s.Address] = struct{}{}
+	}
+	assert.Equal(t, len(seen), 3)
+	assert.DeepEqual(t, c.ListSigners(), signers)
+
+	// index 0 is free, index 1 collides with already added key, so nothing is added
+	_, err = c.AddSignersFromMnemonic("other", testMnemonic1, 2, KeyOptions{})
+	assert.ErrorContains(t, err, "already exists")
+	assert.Equal(t, len(c.ListSigners()), 3)
+}
+
+// testPrivKeyHex is the key derived from testMnemonic2 with CoinTypeEVM
+const testPrivKeyHex = "0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+func TestClient_AddSignerFromPrivKey(t *testing.T) {
+	c := newKeyringClient()
+
+	mnemonicAddr, err := c.AddSignerWithOptions("mnemonic", testMnemonic2, KeyOptions{CoinType: CoinTypeEVM})
+	assert.NilError(t, err)
+	assert.NilError(t, c.RemoveSigner("mnemonic"))
+
+	addr, err := c.AddSignerFromPrivKey("priv", testPrivKeyHex)
+	assert.NilError(t, err)
+	assert.Equal(t, addr, mnemonicAddr)
+
+	_, err = c.AddSignerFromPrivKey("priv", testPrivKeyHex)
+	assert.ErrorContains(t, err, "duplicate signer")
+
+	_, err = c.AddSignerFromPrivKey("short", "0xabcd")
+	assert.ErrorContains(t, err, "invalid private key length")
+}
+
+func TestClient_AddSignerFromArmor(t *testing.T) {
+	src := newKeyringClient()
+	addr, err := src.AddSigner("src", testMnemonic1)
+	assert.NilError(t, err)
+	armor, err := src.clientCtx.Keyring.ExportPrivKeyArmor("src", "passphrase")
+	assert.NilError(t, err)
+
+	c := newKeyringClient()
+	_, err = c.AddSignerFromArmor("dst", armor, "wrong")
+	assert.ErrorContains(t, err, "failed to decrypt")
+
+	imported, err := c.AddSignerFromArmor("dst", armor, "passphrase")
+	assert.NilError(t, err)
+	assert.Equal(t, imported, addr)
+}
+
+func TestClient_AddSignerFromKeystore(t *testing.T) {
+	privKey, err := ethcrypto.HexToECDSA(strings.TrimPrefix(testPrivKeyHex, "0x"))
+	assert.NilError(t, err)
+
+	keystoreJSON, err := ethkeystore.EncryptKey(&ethkeystore.Key{
+		Id:         uuid.New(),
+		Address:    ethcrypto.PubkeyToAddress(privKey.PublicKey),
+		PrivateKey: privKey,
+	}, "passphrase", ethkeystore.LightScryptN, ethkeystore.LightScryptP)
+	assert.NilError(t, err)
+
+	c := newKeyringClient()
+	privAddr, err := c.AddSignerFromPrivKey("priv", testPrivKeyHex)
+	assert.NilError(t, err)
+	assert.NilError(t, c.RemoveSigner("priv"))
+
+	addr, err := c.AddSignerFromKeystore("keystore", keystoreJSON, "passphrase")
+	assert.NilError(t, err)
+	assert.Equal(t, addr, privAddr)
+}
+
+func TestClient_AddSignerFromPrivKey_ConcurrentSameName(t *testing.T) {
+	c := newKeyringClient()
+	c.clientCtx = c.clientCtx.WithKeyring(slowKeyring{Keyring: c.clientCtx.Keyring})
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		added []string
+	)
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			address, err := c.AddSignerFromPrivKey("imported", hex.EncodeToString(secp256k1.GenPrivKey().Key))
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				assert.Check(t, strings.Contains(err.Error(), "duplicate signer"), err)
+				return
+			}
+			added = append(added, address)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, len(added), 1)
+	assertSignerUsable(t, c, "imported", added[0])
+}
@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrClientClosed is returned by requests of closed client
+var ErrClientClosed = errors.New("client is closed")
+
+type (
+	// Health is the status of the client endpoints
+	Health struct {
+		Endpoints []EndpointHealth
+	}
+
+	// EndpointHealth is the status of a single endpoint
+	EndpointHealth struct {
+		GRPCHost string
+		RPCHost  string
+		// Healthy endpoints receive requests. An endpoint is unhealthy when it is not reachable, catching up,
+		// on another network or lags behind the highest endpoint by more than HealthCheckConfig.MaxBlockLag blocks
+		Healthy bool
+		// Err of the status request
+		Err error
+
+		ChainID    string
+		Height     int64
+		BlockTime  time.Time
+		CatchingUp bool
+		// BlockLag is the amount of blocks the endpoint lags behind the highest endpoint
+		BlockLag int64
+		// TimeLag is the time passed since the latest block of the endpoint
+		TimeLag time.Duration
+		// Latency of the status request
+		Latency time.Duration
+	}
+)
+
+// Healthy reports whether any endpoint is healthy
+func (h *Health) Healthy() bool {
+	for _, e := range h.Endpoints {
+		if e.Healthy {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Close stops health checks and closes connections to the nodes. Requests fail with ErrClientClosed afterwards
+func (c *Client) Close() error {
+	return c.endpoints.close()
+}
+
+// Health requests status of every endpoint, updating their health used for balancing
+func (c *Client) Health(ctx context.Context) (*Health, error) {
+	if c.endpoints.closed.Load() {
+		return nil, ErrClientClosed
+	}
+
+	statuses := c.endpoints.checkHealth(ctx)
+
+	var maxHeight int64
+	for _, s := range statuses {
+		if s.err == nil {
+			maxHeight = max(maxHeight, s.status.SyncInfo.LatestBlockHeight)
+		}
+	}
+
+	h := &Health{}
+	for i, s := range statuses {
+		e := c.endpoints.endpoints[i]
+		eh := EndpointHealth{
+			GRPCHost: e.cfg.GRPCHost,
+			RPCHost:  e.cfg.RPCHost,
+			Healthy:  e.isHealthy(),
+			Err:      s.err,
+			Latency:  s.latency,
+		}
+		if s.err == nil {
+			eh.ChainID = s.status.NodeInfo.Network
+			eh.Height = s.status.SyncInfo.LatestBlockHeight
+			eh.BlockTime = s.status.SyncInfo.LatestBlockTime
+			eh.CatchingUp = s.status.SyncInfo.CatchingUp
+			eh.BlockLag = maxHeight - eh.Height
+			eh.TimeLag = time.Since(eh.BlockTime)
+		}
+		h.Endpoints = append(h.Endpoints, eh)
+	}
+
+	return h, nil
+}
+
+// Ping requests node status, it fails when no node answers or the node is catching up
+func (c *Client) Ping(ctx context.Context) error {
+	st, err := c.clientCtx.Client.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("Status: %w", err)
+	}
+	if st.SyncInfo.CatchingUp {
+		return fmt.Errorf("node is catching up at height %d", st.SyncInfo.LatestBlockHeight)
+	}
+
+	return nil
+}
+
+// verifyChainID checks that the nodes are on the network of the chain ID, so txs of one network are not signed against another.
+// Unreachable endpoints are skipped, but at least one endpoint has to be verified
+func (p *endpointPool) verifyChainID(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, p.health.Timeout)
+	defer cancel()
+
+	var (
+		verified bool
+		errs     []error
+	)
+	for i, s := range p.checkHealth(ctx) {
+		host := p.endpoints[i].cfg.RPCHost
+		if s.err != nil {
+			p.logger.WarnContext(ctx, "can't verify chain ID of endpoint", "rpc_host", host, "error", s.err)
+			errs = append(errs, fmt.Errorf("%s: %w", host, s.err))
+			continue
+		}
+		if network := s.status.NodeInfo.Network; network != p.chainID {
+			return fmt.Errorf("node %s is on network %s, not on ChainID %s", host, network, p.chainID)
+		}
+		verified = true
+	}
+	if !verified {
+		return fmt.Errorf("can't verify chain ID, set SkipChainIDCheck to create client without reachable nodes: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
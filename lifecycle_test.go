@@ -0,0 +1,93 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func stubConfig(stubs ...*stubRPC) Config {
+	cfg := Config{ChainID: ChainIDTestnet, InsecureGRPC: true}
+	for _, s := range stubs {
+		cfg.Endpoints = append(cfg.Endpoints, Endpoint{GRPCHost: "localhost:9090", RPCHost: s.server.URL})
+	}
+
+	return cfg
+}
+
+func TestNewClient_ChainIDCheck(t *testing.T) {
+	stub := newStubRPC(t)
+	stub.network = string(ChainIDTestnet)
+	c, err := NewClient(stubConfig(stub))
+	assert.NilError(t, err)
+	assert.NilError(t, c.Close())
+
+	stub.network = string(ChainIDMainnet)
+	_, err = NewClient(stubConfig(stub))
+	assert.ErrorContains(t, err, "is on network pacific-1, not on ChainID atlantic-2")
+
+	cfg := stubConfig(stub)
+	cfg.SkipChainIDCheck = true
+	c, err = NewClient(cfg)
+	assert.NilError(t, err)
+	assert.NilError(t, c.Close())
+
+	stub.server.Close()
+	_, err = NewClient(stubConfig(stub))
+	assert.ErrorContains(t, err, "can't verify chain ID")
+}
+
+func TestConfig_ValidateChainID(t *testing.T) {
+	cfg := Config{GRPCHost: "localhost:9090", RPCHost: "http://localhost:26657"}
+	assert.ErrorContains(t, cfg.Validate(), "empty ChainID")
+
+	cfg.SkipChainIDCheck = true
+	assert.NilError(t, cfg.Validate())
+
+	cfg.ChainID, cfg.SkipChainIDCheck = ChainIDTestnet, false
+	assert.NilError(t, cfg.Validate())
+}
+
+func TestClient_Health(t *testing.T) {
+	synced, lagging := newStubRPC(t), newStubRPC(t)
+	synced.network, lagging.network = string(ChainIDTestnet), string(ChainIDTestnet)
+	synced.height, lagging.height = 100, 10
+
+	c, err := NewClient(stubConfig(synced, lagging))
+	assert.NilError(t, err)
+	defer c.Close()
+
+	h, err := c.Health(context.Background())
+	assert.NilError(t, err)
+	assert.Assert(t, h.Healthy())
+	assert.Equal(t, len(h.Endpoints), 2)
+	assert.Equal(t, h.Endpoints[0].RPCHost, synced.server.URL)
+	assert.Assert(t, h.Endpoints[0].Healthy)
+	assert.Equal(t, h.Endpoints[0].ChainID, string(ChainIDTestnet))
+	assert.Equal(t, h.Endpoints[0].Height, int64(100))
+	assert.Assert(t, h.Endpoints[0].Latency > 0)
+	assert.Assert(t, !h.Endpoints[1].Healthy)
+	assert.Equal(t, h.Endpoints[1].BlockLag, int64(90))
+
+	assert.NilError(t, c.Ping(context.Background()))
+	synced.catchingUp = true
+	assert.ErrorContains(t, c.Ping(context.Background()), "catching up")
+}
+
+func TestClient_Close(t *testing.T) {
+	stub := newStubRPC(t)
+	stub.network = string(ChainIDTestnet)
+	c, err := NewClient(stubConfig(stub))
+	assert.NilError(t, err)
+
+	assert.NilError(t, c.Close())
+	assert.NilError(t, c.Close())
+
+	_, err = c.GetBankBalance(context.Background(), "sei1mce4kk5a0spf2nlg9z6a7ryncz3qksgg4wr7fs", DefaultDenom)
+	assert.Assert(t, errors.Is(err, ErrClientClosed), err)
+	assert.Assert(t, errors.Is(c.Ping(context.Background()), ErrClientClosed))
+	_, err = c.Health(context.Background())
+	assert.Assert(t, errors.Is(err, ErrClientClosed), err)
+}
@@ -0,0 +1,167 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// redacted replaces values of redacted log attributes and message fields
+const redacted = "[REDACTED]"
+
+// sensitiveLogKeys are log attribute keys and message fields, which are always redacted
+var sensitiveLogKeys = []string{
+	"mnemonic", "signature", "signatures", "priv_key", "private_key", "privkey",
+	"passphrase", "password", "bearer_token", "authorization", "armor", "keystore",
+}
+
+// discardLogger discards logs, it is used when no logger is set
+var discardLogger = slog.New(discardHandler{})
+
+// newLogger returns logger of the config redacting sensitive attributes and LogRedactFields, logs are discarded when no logger is set
+func newLogger(cfg Config) *slog.Logger {
+	if cfg.Logger == nil {
+		return discardLogger
+	}
+
+	keys := make(map[string]bool, len(sensitiveLogKeys)+len(cfg.LogRedactFields))
+	for _, k := range sensitiveLogKeys {
+		keys[k] = true
+	}
+	for _, k := range cfg.LogRedactFields {
+		keys[strings.ToLower(k)] = true
+	}
+
+	return slog.New(&redactHandler{handler: cfg.Logger.Handler(), keys: keys})
+}
+
+// log returns logger of the client, logs of clients created without NewClient are discarded
+func (c *Client) log() *slog.Logger {
+	if c.logger == nil {
+		return discardLogger
+	}
+
+	return c.logger
+}
+
+// discardHandler is slog.Handler discarding logs
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (d discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return d }
+func (d discardHandler) WithGroup(string) slog.Handler           { return d }
+
+// redactHandler is slog.Handler replacing values of the keys with [REDACTED]. Keys are matched case-insensitively,
+// in groups and in fields of json.RawMessage values, which are used to log contract messages
+type redactHandler struct {
+	handler slog.Handler
+	keys    map[string]bool
+}
+
+func (h *redactHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *redactHandler) Handle(ctx context.Context, r slog.Record) error {
+	record := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		record.AddAttrs(h.redact(a))
+		return true
+	})
+
+	return h.handler.Handle(ctx, record)
+}
+
+func (h *redactHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	redactedAttrs := make([]slog.Attr, 0, len(attrs))
+	for _, a := range attrs {
+		redactedAttrs = append(redactedAttrs, h.redact(a))
+	}
+
+	return &redactHandler{handler: h.handler.WithAttrs(redactedAttrs), keys: h.keys}
+}
+
+func (h *redactHandler) WithGroup(name string) slog.Handler {
+	return &redactHandler{handler: h.handler.WithGroup(name), keys: h.keys}
+}
+
+func (h *redactHandler) redact(a slog.Attr) slog.Attr {
+	if h.keys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, redacted)
+	}
+
+	a.Value = a.Value.Resolve()
+	switch a.Value.Kind() {
+	case slog.KindGroup:
+		group := a.Value.Group()
+		attrs := make([]any, 0, len(group))
+		for _, ga := range group {
+			attrs = append(attrs, h.redact(ga))
+		}
+		return slog.Group(a.Key, attrs...)
+	case slog.KindAny:
+		if msg, ok := a.Value.Any().(json.RawMessage); ok {
+			return slog.String(a.Key, redactJSON(msg, h.keys))
+		}
+	}
+
+	return a
+}
+
+// redactJSON replaces values of the keys in JSON objects with [REDACTED], invalid JSON is replaced entirely
+func redactJSON(data []byte, keys map[string]bool) string {
+	var v any
+	err := json.Unmarshal(data, &v)
+	if err != nil {
+		return redacted
+	}
+
+	out, err := json.Marshal(redactValue(v, keys))
+	if err != nil {
+		return redacted
+	}
+
+	return string(out)
+}
+
+func redactValue(v any, keys map[string]bool) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if keys[strings.ToLower(k)] {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(field, keys)
+			}
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item, keys)
+		}
+	}
+
+	return v
+}
+
+// msgLogAttrs returns summaries of the messages for debug logs, contract messages are logged as json.RawMessage to be redacted
+func msgLogAttrs(msgs []sdktypes.Msg) slog.Attr {
+	attrs := make([]any, 0, len(msgs))
+	for i, msg := range msgs {
+		msgAttrs := []any{slog.String("type", sdktypes.MsgTypeURL(msg))}
+		switch msg := msg.(type) {
+		case *wasmtypes.MsgExecuteContract:
+			msgAttrs = append(msgAttrs, slog.String("contract", msg.Contract), slog.Any("msg", json.RawMessage(msg.Msg)), slog.String("funds", msg.Funds.String()))
+		case *wasmtypes.MsgInstantiateContract:
+			msgAttrs = append(msgAttrs, slog.Uint64("code_id", msg.CodeID), slog.String("label", msg.Label), slog.Any("msg", json.RawMessage(msg.Msg)), slog.String("funds", msg.Funds.String()))
+		}
+		attrs = append(attrs, slog.Group(strconv.Itoa(i), msgAttrs...))
+	}
+
+	return slog.Group("msgs", attrs...)
+}
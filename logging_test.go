@@ -0,0 +1,109 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"gotest.tools/assert"
+)
+
+func newBufferLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
+
+func TestNewLogger_Redaction(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(Config{Logger: newBufferLogger(&buf), LogRedactFields: []string{"Recipient"}})
+
+	logger.With("passphrase", "secret").Info("test",
+		"mnemonic", testMnemonic1,
+		slog.Group("sig", "Signature", "c2ln", "pub_key", "cHVi"),
+		"msg", json.RawMessage(`{"transfer":{"recipient":"sei1abc","amount":"1","memo":{"password":"p"}}}`),
+		"invalid", json.RawMessage(`{`),
+		"recipient", "sei1abc",
+	)
+
+	var entry map[string]any
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, entry["passphrase"], redacted)
+	assert.Equal(t, entry["mnemonic"], redacted)
+	assert.DeepEqual(t, entry["sig"], map[string]any{"Signature": redacted, "pub_key": "cHVi"})
+	assert.Equal(t, entry["msg"], `{"transfer":{"amount":"1","memo":{"password":"[REDACTED]"},"recipient":"[REDACTED]"}}`)
+	assert.Equal(t, entry["invalid"], redacted)
+	assert.Equal(t, entry["recipient"], redacted)
+	assert.Assert(t, !strings.Contains(buf.String(), "test test"))
+}
+
+func TestNewLogger_Discard(t *testing.T) {
+	logger := newLogger(Config{})
+	assert.Assert(t, !logger.Enabled(context.Background(), slog.LevelError))
+}
+
+func TestClient_LogWithoutLogger(t *testing.T) {
+	c := &Client{}
+	assert.Assert(t, !c.log().Enabled(context.Background(), slog.LevelError))
+	c.log().Warn("discarded")
+}
+
+func TestEndpointPool_Logger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(Config{Logger: newBufferLogger(&buf)})
+	down, up := newEndpoint(Endpoint{GRPCHost: "down:9090"}, &fakeConn{unavailable: true}, nil), newEndpoint(Endpoint{}, &fakeConn{}, nil)
+	pool := newEndpointPool([]*endpoint{down, up}, Config{}, logger)
+	pool.checkedAt.Store(time.Now().UnixNano())
+
+	_, err := txtypes.NewServiceClient(pool).GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(buf.String(), `"msg":"endpoint request failed, failing over","grpc_host":"down:9090"`))
+}
+
+func TestMsgLogAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(Config{Logger: newBufferLogger(&buf), LogRedactFields: []string{"amount"}})
+
+	logger.Debug("tx", msgLogAttrs([]sdktypes.Msg{&wasmtypes.MsgExecuteContract{
+		Contract: "sei1contract",
+		Msg:      []byte(`{"claim":{"amount":"10"}}`),
+		Funds:    sdktypes.NewCoins(sdktypes.NewInt64Coin(DefaultDenom, 5)),
+	}}))
+
+	var entry struct {
+		Msgs map[string]map[string]string `json:"msgs"`
+	}
+	assert.NilError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.DeepEqual(t, entry.Msgs["0"], map[string]string{
+		"type":     "/cosmwasm.wasm.v1.MsgExecuteContract",
+		"contract": "sei1contract",
+		"msg":      `{"claim":{"amount":"[REDACTED]"}}`,
+		"funds":    "5usei",
+	})
+}
+
+func TestClient_Logging(t *testing.T) {
+	stub := newStubRPC(t, 1)
+	stub.network = string(ChainIDTestnet)
+
+	var buf bytes.Buffer
+	cfg := stubConfig(stub)
+	cfg.Logger = newBufferLogger(&buf)
+	c, err := NewClient(cfg)
+	assert.NilError(t, err)
+	defer c.Close()
+
+	err = c.HandleTxsByHeight(context.Background(), "sei1contract", 1, 1, func(context.Context, []abci.Event) error { return nil })
+	assert.NilError(t, err)
+
+	logs := buf.String()
+	assert.Assert(t, strings.Contains(logs, `"msg":"client created","chain_id":"atlantic-2"`), logs)
+	assert.Assert(t, strings.Contains(logs, `"msg":"request","transport":"rpc","method":"tx_search"`), logs)
+	assert.Assert(t, strings.Contains(logs, `"msg":"txs found","from":1,"to":1,"page":1,"txs":1`), logs)
+}
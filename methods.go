@@ -16,18 +16,26 @@ import (
 	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	"github.com/tendermint/tendermint/rpc/coretypes"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
 	// DefaultDenom is the default denomination for Sei blockchain
 	DefaultDenom = "usei"
 
-	searchByHeightQuery = `tx.height>%d AND tx.height<=%d AND wasm._contract_address CONTAINS '%s'`
-	rangeSize           = 100_000
+	searchByHeightQuery = `tx.height>=%d AND tx.height<=%d AND wasm._contract_address CONTAINS '%s'`
+
+	// DefaultTxSearchWindowSize is the default amount of heights covered by a single TxSearch query
+	DefaultTxSearchWindowSize = 100_000
+	// DefaultTxSearchPageSize is the default and maximum TxSearch page size
+	DefaultTxSearchPageSize = 100
 )
 
 // GetBankBalance queries a Cosmos SDK bank for the balance of a specific account denominated in a specific denom
-func (c *Client) GetBankBalance(ctx context.Context, address, denom string) (*banktypes.QueryBalanceResponse, error) {
+func (c *Client) GetBankBalance(ctx context.Context, address, denom string) (res *banktypes.QueryBalanceResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.GetBankBalance", attribute.String("sei.address", address), attribute.String("sei.denom", denom))
+	defer func() { endSpan(span, err) }()
+
 	// Create a QueryBalanceRequest struct with the provided address and denom
 	req := &banktypes.QueryBalanceRequest{
 		Address: address,
@@ -54,11 +62,12 @@ func (c *Client) Execute(ctx context.Context, signerName, contractAddress, msg s
 	if msg == "" {
 		return resp, errors.New("message is empty")
 	}
-	// Retrieve the signer information for the provided signer name
-	sgn, err := c.getSigner(signerName)
+	// Retrieve the signer information for the provided signer name, it is not rotated until the tx is broadcast
+	sgn, release, err := c.acquireSigner(signerName)
 	if err != nil {
 		return resp, err
 	}
+	defer release()
 	// Create a MsgExecuteContract message with the signer address, contract address, and message
 	message := &wasmtypes.MsgExecuteContract{
 		Sender:   sgn.address.String(),
@@ -94,10 +103,11 @@ func (c *Client) Instantiate(ctx context.Context, signerName string, codeID uint
 		return resp, errors.New("label is empty")
 	}
 
-	sgn, err := c.getSigner(signerName)
+	sgn, release, err := c.acquireSigner(signerName)
 	if err != nil {
 		return resp, err
 	}
+	defer release()
 
 	message := &wasmtypes.MsgInstantiateContract{
 		Sender: sgn.address.String(),
@@ -118,6 +128,9 @@ func (c *Client) Instantiate(ctx context.Context, signerName string, codeID uint
 
 // GetTxByHash retrieves transaction from the network. retries and sleepInterval params can be used to re-retrieve tx in case of error
 func (c *Client) GetTxByHash(ctx context.Context, txHash string, retries uint, sleepInterval time.Duration) (txResp *coretypes.ResultTx, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.GetTxByHash", attribute.String("sei.tx_hash", txHash))
+	defer func() { endSpan(span, err) }()
+
 	cl, err := c.clientCtx.GetNode()
 	if err != nil {
 		return txResp, fmt.Errorf("GetNode: %s", err)
@@ -145,6 +158,7 @@ func (c *Client) GetTxByHash(ctx context.Context, txHash string, retries uint, s
 		if err != nil {
 			// tx not found
 			if strings.Contains(err.Error(), "RPC error -32603") {
+				c.log().DebugContext(ctx, "tx not found", "tx_hash", txHash, "attempt", i+1, "attempts", retries+1)
 				continue
 			}
 
@@ -155,6 +169,7 @@ func (c *Client) GetTxByHash(ctx context.Context, txHash string, retries uint, s
 			continue
 		}
 
+		c.log().DebugContext(ctx, "tx found", "tx_hash", txHash, "height", txResp.Height, "code", txResp.TxResult.Code)
 		if txResp.TxResult.Code != 0 {
 			return txResp, fmt.Errorf("non-zero code: %d", txResp.TxResult.Code)
 		}
@@ -174,7 +189,10 @@ func (c *Client) GetTxByHash(ctx context.Context, txHash string, retries uint, s
 }
 
 // GetLatestHeight retrieves latest height from the network.
-func (c *Client) GetLatestHeight(ctx context.Context) (int64, error) {
+func (c *Client) GetLatestHeight(ctx context.Context) (_ int64, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.GetLatestHeight")
+	defer func() { endSpan(span, err) }()
+
 	tendermintNode, err := c.clientCtx.GetNode()
 	if err != nil {
 		return 0, err
@@ -190,6 +208,9 @@ func (c *Client) GetLatestHeight(ctx context.Context) (int64, error) {
 
 // GetTxMetaResponseByHash retrieves transaction metadata response from the network. retries and sleepInterval params can be used to re-retrieve tx in case of error
 func (c *Client) GetTxMetaResponseByHash(ctx context.Context, txHash string, retries uint, sleepInterval time.Duration) (txResp *txtypes.GetTxResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.GetTxMetaResponseByHash", attribute.String("sei.tx_hash", txHash))
+	defer func() { endSpan(span, err) }()
+
 	// first is request, after n retries
 	for i := range retries + 1 {
 		select {
@@ -206,6 +227,7 @@ func (c *Client) GetTxMetaResponseByHash(ctx context.Context, txHash string, ret
 		txResp, err = c.txClient.GetTx(ctx, &txtypes.GetTxRequest{Hash: txHash})
 		if err != nil {
 			if strings.Contains(err.Error(), "tx not found") {
+				c.log().DebugContext(ctx, "tx not found", "tx_hash", txHash, "attempt", i+1, "attempts", retries+1)
 				continue
 			}
 
@@ -216,6 +238,7 @@ func (c *Client) GetTxMetaResponseByHash(ctx context.Context, txHash string, ret
 			continue
 		}
 
+		c.log().DebugContext(ctx, "tx found", "tx_hash", txHash, "height", txResp.TxResponse.Height, "code", txResp.TxResponse.Code)
 		if txResp.TxResponse.Code != 0 {
 			return txResp, fmt.Errorf("non-zero code: %d", txResp.TxResponse.Code)
 		}
@@ -235,66 +258,120 @@ func (c *Client) GetTxMetaResponseByHash(ctx context.Context, txHash string, ret
 }
 
 // HandleTxsByHeight retrieves contract transaction by height and process via callback.
-func (c *Client) HandleTxsByHeight(ctx context.Context, contractAddress string, heightFrom, heightTo int64, acknowledge func(ctx context.Context, msg []abci.Event) error) error {
+// Both heightFrom and heightTo are inclusive. The appended tx hash event is lowercase hex, unlike uppercase TxRecord.Hash
+func (c *Client) HandleTxsByHeight(ctx context.Context, contractAddress string, heightFrom, heightTo int64, acknowledge func(ctx context.Context, msg []abci.Event) error) (err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.HandleTxsByHeight", heightAttributes(contractAddress, heightFrom, heightTo)...)
+	defer func() { endSpan(span, err) }()
+
+	return c.handleTxsByHeight(ctx, contractAddress, heightFrom, heightTo, func(ctx context.Context, tx *coretypes.ResultTx) error {
+		// Create tx_hash event
+		txHashEvent := abci.Event{
+			Type: "tx",
+			Attributes: []abci.EventAttribute{
+				{
+					Key:   []byte("hash"),
+					Value: []byte(hex.EncodeToString(tx.Hash)),
+				},
+			},
+		}
+
+		// Create tx_height event
+		txHeightEvent := abci.Event{
+			Type: "tx",
+			Attributes: []abci.EventAttribute{
+				{
+					Key:   []byte("height"),
+					Value: []byte(strconv.FormatInt(tx.Height, 10)),
+				},
+			},
+		}
+
+		tx.TxResult.Events = append(tx.TxResult.Events, []abci.Event{txHeightEvent, txHashEvent}...)
+		return acknowledge(ctx, tx.TxResult.Events)
+	})
+}
+
+// HandleDecodedTxsByHeight retrieves contract transaction by height, decodes them and process via callback.
+// Both heightFrom and heightTo are inclusive.
+func (c *Client) HandleDecodedTxsByHeight(ctx context.Context, contractAddress string, heightFrom, heightTo int64, acknowledge func(ctx context.Context, tx *TxRecord) error) (err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.HandleDecodedTxsByHeight", heightAttributes(contractAddress, heightFrom, heightTo)...)
+	defer func() { endSpan(span, err) }()
+
 	tendermintNode, err := c.clientCtx.GetNode()
 	if err != nil {
 		return fmt.Errorf("clientCtx.GetNode: %w", err)
 	}
 
-	txsCount := 100
-	from := heightFrom
-	to := min(heightFrom+rangeSize, heightTo)
+	blockTimes := newBlockTimeCache(tendermintNode)
 
-	for {
-		page := 1
-		query := fmt.Sprintf(searchByHeightQuery, from, to, contractAddress)
+	return c.handleTxsByHeight(ctx, contractAddress, heightFrom, heightTo, func(ctx context.Context, tx *coretypes.ResultTx) error {
+		record, err := c.DecodeTx(tx)
+		if err != nil {
+			return fmt.Errorf("DecodeTx %X: %w", tx.Hash, err)
+		}
 
-		for {
-			resp, err := tendermintNode.TxSearch(ctx, query, true, &page, &txsCount, "asc")
-			if err != nil {
-				return fmt.Errorf("tendermintNode.TxSearch: %w", err)
-			}
+		record.BlockTime, err = blockTimes.get(ctx, tx.Height)
+		if err != nil {
+			return err
+		}
 
-			for i := range resp.Txs {
-				// Create tx_hash event
-				txHashEvent := abci.Event{
-					Type: "tx",
-					Attributes: []abci.EventAttribute{
-						{
-							Key:   []byte("hash"),
-							Value: []byte(hex.EncodeToString(resp.Txs[i].Hash)),
-						},
-					},
-				}
+		return acknowledge(ctx, record)
+	})
+}
 
-				// Create tx_height event
-				txHeightEvent := abci.Event{
-					Type: "tx",
-					Attributes: []abci.EventAttribute{
-						{
-							Key:   []byte("height"),
-							Value: []byte(strconv.FormatInt(resp.Txs[i].Height, 10)),
-						},
-					},
-				}
+// handleTxsByHeight passes contract transactions in [heightFrom, heightTo] to the callback
+func (c *Client) handleTxsByHeight(ctx context.Context, contractAddress string, heightFrom, heightTo int64, handle func(ctx context.Context, tx *coretypes.ResultTx) error) error {
+	return c.handleTxsByRange(ctx, TxSearchConfig{
+		ContractAddress: contractAddress,
+		Heights:         HeightRange{From: heightFrom, To: heightTo},
+	}, handle)
+}
+
+// HandleTxsByRange retrieves contract transactions in the height range window by window and process via callback.
+// Pages of a window are requested until TotalCount txs are received.
+func (c *Client) HandleTxsByRange(ctx context.Context, cfg TxSearchConfig, handle func(ctx context.Context, tx *coretypes.ResultTx) error) (err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.HandleTxsByRange", heightAttributes(cfg.ContractAddress, cfg.Heights.From, cfg.Heights.To)...)
+	defer func() { endSpan(span, err) }()
+
+	return c.handleTxsByRange(ctx, cfg, handle)
+}
+
+// handleTxsByRange passes contract transactions in the height range to the callback
+func (c *Client) handleTxsByRange(ctx context.Context, cfg TxSearchConfig, handle func(ctx context.Context, tx *coretypes.ResultTx) error) error {
+	err := cfg.Validate()
+	if err != nil {
+		return err
+	}
+
+	tendermintNode, err := c.clientCtx.GetNode()
+	if err != nil {
+		return fmt.Errorf("clientCtx.GetNode: %w", err)
+	}
+
+	heightFrom, heightTo := cfg.Heights.Bounds()
+	for from := heightFrom; ; {
+		to := min(from+cfg.WindowSize-1, heightTo)
+		query := fmt.Sprintf(searchByHeightQuery, from, to, cfg.ContractAddress)
+
+		err = searchTxPages(ctx, tendermintNode, query, cfg.PageSize, true, func(page int, resp *coretypes.ResultTxSearch) error {
+			c.log().DebugContext(ctx, "txs found", "from", from, "to", to, "page", page, "txs", len(resp.Txs), "total", resp.TotalCount)
 
-				resp.Txs[i].TxResult.Events = append(resp.Txs[i].TxResult.Events, []abci.Event{txHeightEvent, txHashEvent}...)
-				err = acknowledge(ctx, resp.Txs[i].TxResult.Events)
+			for i := range resp.Txs {
+				err := handle(ctx, resp.Txs[i])
 				if err != nil {
 					return err
 				}
 			}
 
-			if len(resp.Txs) < 100 {
-				if to == heightTo {
-					return nil
-				}
-				from = to
-				to = min(from+rangeSize, heightTo)
-				break
-			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
 
-			page++
+		if to == heightTo {
+			return nil
 		}
+		from = to + 1
 	}
 }
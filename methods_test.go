@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"gotest.tools/assert"
+)
+
+const testContractAddress = "sei1contract"
+
+func collectHeights(t *testing.T, c *Client, cfg TxSearchConfig) []int64 {
+	var heights []int64
+	err := c.HandleTxsByRange(context.Background(), cfg, func(_ context.Context, tx *coretypes.ResultTx) error {
+		heights = append(heights, tx.Height)
+		return nil
+	})
+	assert.NilError(t, err)
+
+	return heights
+}
+
+func TestClient_HandleTxsByRange_Bounds(t *testing.T) {
+	stub := newStubRPC(t, 1, 5, 10, 11, 20)
+	c := newStubClient(t, stub)
+
+	tests := []struct {
+		name    string
+		heights HeightRange
+		want    []int64
+	}{
+		{name: "inclusive", heights: HeightRange{From: 5, To: 11}, want: []int64{5, 10, 11}},
+		{name: "exclude from", heights: HeightRange{From: 5, To: 11, ExcludeFrom: true}, want: []int64{10, 11}},
+		{name: "exclude to", heights: HeightRange{From: 5, To: 11, ExcludeTo: true}, want: []int64{5, 10}},
+		{name: "single height", heights: HeightRange{From: 20, To: 20}, want: []int64{20}},
+		{name: "first height", heights: HeightRange{From: 1, To: 4}, want: []int64{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := collectHeights(t, c, TxSearchConfig{ContractAddress: testContractAddress, Heights: tt.heights})
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestClient_HandleTxsByRange_EmptyRange(t *testing.T) {
+	c := newStubClient(t, newStubRPC(t))
+
+	err := c.HandleTxsByRange(context.Background(), TxSearchConfig{
+		ContractAddress: testContractAddress,
+		Heights:         HeightRange{From: 5, To: 5, ExcludeTo: true},
+	}, func(context.Context, *coretypes.ResultTx) error { return nil })
+	assert.ErrorContains(t, err, "empty height range")
+}
+
+func TestClient_HandleTxsByHeight_FromZero(t *testing.T) {
+	c := newStubClient(t, newStubRPC(t, 1, 2))
+
+	var txs int
+	err := c.HandleTxsByHeight(context.Background(), testContractAddress, 0, 2, func(context.Context, []abci.Event) error {
+		txs++
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, txs, 2)
+
+	err = c.HandleTxsByHeight(context.Background(), testContractAddress, -1, 2, func(context.Context, []abci.Event) error { return nil })
+	assert.ErrorContains(t, err, "negative From")
+}
+
+func TestClient_HandleTxsByRange_WindowBoundaries(t *testing.T) {
+	stub := newStubRPC(t, 1, 5, 6, 10, 11, 15, 16, 20)
+	c := newStubClient(t, stub)
+
+	got := collectHeights(t, c, TxSearchConfig{
+		ContractAddress: testContractAddress,
+		Heights:         HeightRange{From: 1, To: 20},
+		WindowSize:      5,
+	})
+	assert.DeepEqual(t, got, []int64{1, 5, 6, 10, 11, 15, 16, 20})
+	// windows [1,5] [6,10] [11,15] [16,20], one page each
+	assert.Equal(t, stub.searchCount(), 4)
+}
+
+func TestClient_HandleTxsByRange_ExactPageMultiple(t *testing.T) {
+	heights := make([]int64, 0, 20)
+	for i := range 20 {
+		heights = append(heights, int64(10+i/5))
+	}
+	stub := newStubRPC(t, heights...)
+	c := newStubClient(t, stub)
+
+	got := collectHeights(t, c, TxSearchConfig{
+		ContractAddress: testContractAddress,
+		Heights:         HeightRange{From: 1, To: 100},
+		PageSize:        10,
+	})
+	assert.DeepEqual(t, got, heights)
+	// TotalCount stops paging, no extra empty page is requested
+	assert.Equal(t, stub.searchCount(), 2)
+}
+
+func TestClient_HandleTxsByHeight_IncludesHeightFrom(t *testing.T) {
+	stub := newStubRPC(t, 100, 101, 200)
+	c := newStubClient(t, stub)
+
+	var hashes int
+	err := c.HandleTxsByHeight(context.Background(), testContractAddress, 100, 200, func(_ context.Context, events []abci.Event) error {
+		for _, e := range events {
+			if e.Type == "tx" && string(e.Attributes[0].Key) == "hash" {
+				hashes++
+			}
+		}
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, hashes, 3)
+}
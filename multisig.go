@@ -0,0 +1,269 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+)
+
+// multisigSignMode is the only sign mode legacy amino multisig members can sign with
+const multisigSignMode = signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+
+// NewMultisigPubKey returns legacy amino multisig public key of the member keys, threshold of which must sign txs.
+// Members are sorted by address when sortByAddress is set, as done by `seid keys add --multisig` without --nosort
+func NewMultisigPubKey(threshold int, pubKeys []cryptotypes.PubKey, sortByAddress bool) (cryptotypes.PubKey, error) {
+	if threshold <= 0 {
+		return nil, errors.New("threshold must be positive")
+	}
+	if threshold > len(pubKeys) {
+		return nil, fmt.Errorf("threshold %d is greater than amount of keys %d", threshold, len(pubKeys))
+	}
+
+	keys := append([]cryptotypes.PubKey(nil), pubKeys...)
+	if sortByAddress {
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i].Address(), keys[j].Address()) < 0
+		})
+	}
+
+	return kmultisig.NewLegacyAminoPubKey(threshold, keys), nil
+}
+
+// MultisigAddress returns address of the multisig public key
+func MultisigAddress(multisigPubKey cryptotypes.PubKey) string {
+	return sdktypes.AccAddress(multisigPubKey.Address()).String()
+}
+
+// BuildUnsignedMultisig works as BuildUnsigned for txs sent from the multisig account
+func (c *Client) BuildUnsignedMultisig(ctx context.Context, multisigPubKey cryptotypes.PubKey, msgs ...sdktypes.Msg) (*OfflineTx, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("no messages")
+	}
+
+	multisigKey, err := toMultisigKey(multisigPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	txf, txn, err := c.buildTx(ctx, sdktypes.AccAddress(multisigKey.Address()), multisigKey, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.encodeOfflineTx(txn.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	res.AccountNumber = txf.AccountNumber()
+	res.Sequence = txf.Sequence()
+
+	return res, nil
+}
+
+// MultisigSignDoc returns the amino JSON sign doc of the unsigned multisig tx, which every member signs
+func (c *Client) MultisigSignDoc(unsigned *OfflineTx) ([]byte, error) {
+	decoded, err := c.decodeOfflineTx(offlineTxBytes(unsigned))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.multisigSignBytes(unsigned, decoded)
+}
+
+// SignMultisig signs the unsigned multisig tx with the member signer and returns its partial signature
+// in the format of `seid tx sign --multisig` output. It does not access the network
+func (c *Client) SignMultisig(ctx context.Context, signerName string, multisigPubKey cryptotypes.PubKey, unsigned *OfflineTx) ([]byte, error) {
+	multisigKey, err := toMultisigKey(multisigPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sgn, release, err := c.acquireSigner(signerName)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	if !isMultisigMember(multisigKey, sgn.key.PubKey()) {
+		return nil, fmt.Errorf("signer %s is not a member of multisig %s", signerName, MultisigAddress(multisigKey))
+	}
+
+	decoded, err := c.decodeMultisigTx(multisigKey, unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	signBytes, err := c.multisigSignBytes(unsigned, decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	sigBytes, err := sgn.key.Sign(ctx, signBytes)
+	if err != nil {
+		return nil, fmt.Errorf("Sign: %w", err)
+	}
+
+	sig := signing.SignatureV2{
+		PubKey:   sgn.key.PubKey(),
+		Data:     &signing.SingleSignatureData{SignMode: multisigSignMode, Signature: sigBytes},
+		Sequence: unsigned.Sequence,
+	}
+
+	res, err := c.clientCtx.TxConfig.MarshalSignatureJSON([]signing.SignatureV2{sig})
+	if err != nil {
+		return nil, fmt.Errorf("MarshalSignatureJSON: %w", err)
+	}
+
+	return res, nil
+}
+
+// CombineMultisig verifies partial signatures of the members, produced by SignMultisig or `seid tx sign --multisig`,
+// and combines them into the signed multisig tx, which can be broadcast with BroadcastSigned
+func (c *Client) CombineMultisig(multisigPubKey cryptotypes.PubKey, unsigned *OfflineTx, signatures ...[]byte) (*OfflineTx, error) {
+	multisigKey, err := toMultisigKey(multisigPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := c.decodeMultisigTx(multisigKey, unsigned)
+	if err != nil {
+		return nil, err
+	}
+
+	txn, err := c.clientCtx.TxConfig.WrapTxBuilder(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("WrapTxBuilder: %w", err)
+	}
+
+	signerData := authsigning.SignerData{
+		ChainID:       c.txFactory.ChainID(),
+		AccountNumber: unsigned.AccountNumber,
+		Sequence:      unsigned.Sequence,
+	}
+
+	multisigSig := multisig.NewMultisig(len(multisigKey.PubKeys))
+	signed := make(map[string]struct{})
+	for _, sigJSON := range signatures {
+		sigs, err := c.clientCtx.TxConfig.UnmarshalSignatureJSON(sigJSON)
+		if err != nil {
+			return nil, fmt.Errorf("UnmarshalSignatureJSON: %w", err)
+		}
+
+		for _, sig := range sigs {
+			address := sdktypes.AccAddress(sig.PubKey.Address()).String()
+			if !isMultisigMember(multisigKey, sig.PubKey) {
+				return nil, fmt.Errorf("%s is not a member of multisig %s", address, MultisigAddress(multisigKey))
+			}
+
+			err = authsigning.VerifySignature(sig.PubKey, signerData, sig.Data, c.clientCtx.TxConfig.SignModeHandler(), decoded)
+			if err != nil {
+				return nil, fmt.Errorf("invalid signature of %s: %w", address, err)
+			}
+
+			err = multisig.AddSignatureV2(multisigSig, sig, multisigKey.GetPubKeys())
+			if err != nil {
+				return nil, fmt.Errorf("AddSignatureV2: %w", err)
+			}
+			signed[address] = struct{}{}
+		}
+	}
+
+	if uint(len(signed)) < multisigKey.GetThreshold() {
+		return nil, fmt.Errorf("%d signatures collected, %d required", len(signed), multisigKey.GetThreshold())
+	}
+
+	err = txn.SetSignatures(signing.SignatureV2{
+		PubKey:   multisigKey,
+		Data:     multisigSig,
+		Sequence: unsigned.Sequence,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("SetSignatures: %w", err)
+	}
+
+	res, err := c.encodeOfflineTx(txn.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	res.AccountNumber = unsigned.AccountNumber
+	res.Sequence = unsigned.Sequence
+
+	return res, nil
+}
+
+// decodeMultisigTx decodes the unsigned tx, checking it is sent from the multisig account
+func (c *Client) decodeMultisigTx(multisigKey *kmultisig.LegacyAminoPubKey, unsigned *OfflineTx) (sdktypes.Tx, error) {
+	decoded, err := c.decodeOfflineTx(offlineTxBytes(unsigned))
+	if err != nil {
+		return nil, err
+	}
+
+	signers := decoded.GetMsgs()[0].GetSigners()
+	if len(signers) == 0 || !bytes.Equal(signers[0], multisigKey.Address()) {
+		return nil, fmt.Errorf("tx is not sent from multisig %s", MultisigAddress(multisigKey))
+	}
+
+	return decoded, nil
+}
+
+func (c *Client) multisigSignBytes(unsigned *OfflineTx, decoded sdktypes.Tx) ([]byte, error) {
+	signBytes, err := c.clientCtx.TxConfig.SignModeHandler().GetSignBytes(multisigSignMode, authsigning.SignerData{
+		ChainID:       c.txFactory.ChainID(),
+		AccountNumber: unsigned.AccountNumber,
+		Sequence:      unsigned.Sequence,
+	}, decoded)
+	if err != nil {
+		return nil, fmt.Errorf("GetSignBytes: %w", err)
+	}
+
+	return signBytes, nil
+}
+
+// multisigSimSignature returns empty signatures of threshold members, so the simulation consumes the multisig verification gas
+func multisigSimSignature(multisigKey *kmultisig.LegacyAminoPubKey) *signing.MultiSignatureData {
+	sig := multisig.NewMultisig(len(multisigKey.PubKeys))
+	for i := 0; i < int(multisigKey.GetThreshold()); i++ {
+		multisig.AddSignature(sig, &signing.SingleSignatureData{SignMode: multisigSignMode}, i)
+	}
+
+	return sig
+}
+
+func toMultisigKey(pubKey cryptotypes.PubKey) (*kmultisig.LegacyAminoPubKey, error) {
+	multisigKey, ok := pubKey.(*kmultisig.LegacyAminoPubKey)
+	if !ok {
+		return nil, fmt.Errorf("%T is not a legacy amino multisig public key", pubKey)
+	}
+
+	return multisigKey, nil
+}
+
+func isMultisigMember(multisigKey *kmultisig.LegacyAminoPubKey, pubKey cryptotypes.PubKey) bool {
+	for _, member := range multisigKey.GetPubKeys() {
+		if member.Equals(pubKey) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func offlineTxBytes(tx *OfflineTx) []byte {
+	if tx == nil {
+		return nil
+	}
+	if len(tx.Bytes) > 0 {
+		return tx.Bytes
+	}
+
+	return tx.JSON
+}
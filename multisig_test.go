@@ -0,0 +1,116 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"gotest.tools/assert"
+)
+
+func TestNewMultisigPubKey(t *testing.T) {
+	c := newKeyringClient()
+	var pubKeys []cryptotypes.PubKey
+	for _, m := range []string{testMnemonic1, testMnemonic2, testMnemonic3} {
+		sgn, err := c.newMnemonicKey(m[:5], m, KeyOptions{})
+		assert.NilError(t, err)
+		pubKeys = append(pubKeys, sgn.key.PubKey())
+	}
+
+	_, err := NewMultisigPubKey(4, pubKeys, true)
+	assert.ErrorContains(t, err, "greater than amount of keys")
+	_, err = NewMultisigPubKey(0, pubKeys, true)
+	assert.ErrorContains(t, err, "must be positive")
+
+	// sorted keys do not depend on the member order
+	sorted, err := NewMultisigPubKey(2, pubKeys, true)
+	assert.NilError(t, err)
+	reversed, err := NewMultisigPubKey(2, []cryptotypes.PubKey{pubKeys[2], pubKeys[1], pubKeys[0]}, true)
+	assert.NilError(t, err)
+	assert.Equal(t, MultisigAddress(sorted), MultisigAddress(reversed))
+
+	unsorted, err := NewMultisigPubKey(2, []cryptotypes.PubKey{pubKeys[2], pubKeys[1], pubKeys[0]}, false)
+	assert.NilError(t, err)
+	assert.Assert(t, MultisigAddress(unsorted) != MultisigAddress(sorted))
+}
+
+func TestClient_Multisig(t *testing.T) {
+	c := newTxClient()
+	ctx := context.Background()
+
+	var pubKeys []cryptotypes.PubKey
+	for name, mnemonic := range map[string]string{"m1": testMnemonic1, "m2": testMnemonic2, "m3": testMnemonic3} {
+		_, err := c.AddSigner(name, mnemonic)
+		assert.NilError(t, err)
+		sgn, err := c.getSigner(name)
+		assert.NilError(t, err)
+		pubKeys = append(pubKeys, sgn.key.PubKey())
+	}
+	_, err := c.AddSignerWithOptions("outsider", testMnemonic1, KeyOptions{Index: 1})
+	assert.NilError(t, err)
+
+	multisigKey, err := NewMultisigPubKey(2, pubKeys, true)
+	assert.NilError(t, err)
+	from := sdktypes.AccAddress(multisigKey.Address())
+
+	msg := banktypes.NewMsgSend(from, from, sdktypes.NewCoins(sdktypes.NewInt64Coin(DefaultDenom, 1)))
+
+	// the simulation carries empty signatures of threshold members
+	simTxBytes, err := c.buildSimTx(c.txFactory, multisigKey, msg)
+	assert.NilError(t, err)
+	simTx, err := c.decodeOfflineTx(simTxBytes)
+	assert.NilError(t, err)
+	simSigs, err := simTx.(authsigning.SigVerifiableTx).GetSignaturesV2()
+	assert.NilError(t, err)
+	assert.Equal(t, len(simSigs[0].Data.(*signing.MultiSignatureData).Signatures), 2)
+
+	txn, err := c.txFactory.BuildUnsignedTx(msg)
+	assert.NilError(t, err)
+	unsigned, err := c.encodeOfflineTx(txn.GetTx())
+	assert.NilError(t, err)
+	unsigned.AccountNumber, unsigned.Sequence = 11, 2
+
+	signDoc, err := c.MultisigSignDoc(unsigned)
+	assert.NilError(t, err)
+	assert.Assert(t, len(signDoc) > 0 && signDoc[0] == '{')
+
+	_, err = c.SignMultisig(ctx, "outsider", multisigKey, unsigned)
+	assert.ErrorContains(t, err, "is not a member")
+
+	sig1, err := c.SignMultisig(ctx, "m1", multisigKey, unsigned)
+	assert.NilError(t, err)
+	sig3, err := c.SignMultisig(ctx, "m3", multisigKey, unsigned)
+	assert.NilError(t, err)
+
+	_, err = c.CombineMultisig(multisigKey, unsigned, sig1)
+	assert.ErrorContains(t, err, "1 signatures collected, 2 required")
+
+	// signature of another sequence does not verify
+	stale := *unsigned
+	stale.Sequence = 1
+	staleSig, err := c.SignMultisig(ctx, "m2", multisigKey, &stale)
+	assert.NilError(t, err)
+	_, err = c.CombineMultisig(multisigKey, unsigned, sig1, staleSig)
+	assert.ErrorContains(t, err, "invalid signature")
+
+	signed, err := c.CombineMultisig(multisigKey, unsigned, sig1, sig3)
+	assert.NilError(t, err)
+
+	decoded, err := c.decodeOfflineTx(signed.JSON)
+	assert.NilError(t, err)
+	sigs, err := decoded.(authsigning.SigVerifiableTx).GetSignaturesV2()
+	assert.NilError(t, err)
+	assert.Equal(t, len(sigs), 1)
+	assert.NilError(t, authsigning.VerifySignature(multisigKey, authsigning.SignerData{
+		ChainID:       testChainID,
+		AccountNumber: 11,
+		Sequence:      2,
+	}, sigs[0].Data, c.clientCtx.TxConfig.SignModeHandler(), decoded))
+
+	_, err = c.BroadcastSigned(ctx, signed.Bytes)
+	assert.NilError(t, err)
+}
@@ -0,0 +1,96 @@
+package sdk
+
+import (
+	"fmt"
+	"sort"
+)
+
+// NetworkLocal is the name of the local network preset, e.g. a node started by sei-chain scripts
+const NetworkLocal = "local"
+
+// Network is a preset of a Sei network with its public endpoints, fee denom and gas price
+type Network struct {
+	ChainID  ChainID
+	GRPCHost string
+	RPCHost  string
+	// InsecureGRPC is set for nodes without TLS
+	InsecureGRPC bool
+	// Denom is the denom fees are paid with
+	Denom string
+	// GasPrice is the gas price with denom
+	GasPrice string
+}
+
+// Presets of known networks
+var (
+	NetworkMainnet = Network{
+		ChainID:  ChainIDMainnet,
+		GRPCHost: "grpc.sei-apis.com:443",
+		RPCHost:  "https://rpc.sei-apis.com",
+		Denom:    DefaultDenom,
+		GasPrice: DefaultGasPriceWithDenom,
+	}
+	NetworkTestnet = Network{
+		ChainID:  ChainIDTestnet,
+		GRPCHost: "grpc-testnet.sei-apis.com:443",
+		RPCHost:  "https://rpc-testnet.sei-apis.com",
+		Denom:    DefaultDenom,
+		GasPrice: DefaultGasPriceWithDenom,
+	}
+	NetworkDevnet = Network{
+		ChainID:  ChainIDDevnet,
+		GRPCHost: "grpc-arctic-1.sei-apis.com:443",
+		RPCHost:  "https://rpc-arctic-1.sei-apis.com",
+		Denom:    DefaultDenom,
+		GasPrice: DefaultGasPriceWithDenom,
+	}
+	NetworkLocalnet = Network{
+		ChainID:      ChainIDLocal,
+		GRPCHost:     "localhost:9090",
+		RPCHost:      "http://localhost:26657",
+		InsecureGRPC: true,
+		Denom:        DefaultDenom,
+		GasPrice:     DefaultGasPriceWithDenom,
+	}
+)
+
+// networks are the presets by name, public networks are named by chain ID
+var networks = map[string]Network{
+	string(ChainIDMainnet): NetworkMainnet,
+	string(ChainIDTestnet): NetworkTestnet,
+	string(ChainIDDevnet):  NetworkDevnet,
+	NetworkLocal:           NetworkLocalnet,
+}
+
+// NetworkByName returns preset by name: pacific-1, atlantic-2, arctic-1 or local
+func NetworkByName(name string) (Network, error) {
+	n, ok := networks[name]
+	if !ok {
+		return Network{}, fmt.Errorf("unknown network %s, known networks are %v", name, NetworkNames())
+	}
+
+	return n, nil
+}
+
+// NetworkNames returns sorted names of the presets
+func NetworkNames() []string {
+	names := make([]string, 0, len(networks))
+	for name := range networks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Config returns client config connecting to the network
+func (n Network) Config() Config {
+	return Config{
+		GRPCHost:     n.GRPCHost,
+		RPCHost:      n.RPCHost,
+		ChainID:      n.ChainID,
+		InsecureGRPC: n.InsecureGRPC,
+		Denom:        n.Denom,
+		GasPrice:     n.GasPrice,
+	}
+}
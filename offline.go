@@ -0,0 +1,156 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+)
+
+// OfflineTx is a tx encoded for transfer between online and air-gapped machines
+type OfflineTx struct {
+	// JSON is the tx in the format of `seid tx sign` input and output
+	JSON []byte
+	// Bytes is the protobuf encoded tx, as broadcast to the network
+	Bytes []byte
+	// AccountNumber of the signer, needed to sign the tx offline
+	AccountNumber uint64
+	// Sequence of the signer, needed to sign the tx offline
+	Sequence uint64
+}
+
+// BuildUnsigned builds unsigned tx of the messages sent from the address, so it can be signed offline.
+// It queries account number and sequence and estimates gas, so it requires network access.
+// The public key of an added signer with the address is used for the simulation if there is one
+func (c *Client) BuildUnsigned(ctx context.Context, fromAddress string, msgs ...sdktypes.Msg) (*OfflineTx, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("no messages")
+	}
+
+	address, err := sdktypes.AccAddressFromBech32(fromAddress)
+	if err != nil {
+		return nil, fmt.Errorf("AccAddressFromBech32: %w", err)
+	}
+
+	var pubKey cryptotypes.PubKey = &secp256k1.PubKey{}
+	for _, s := range c.signers.list() {
+		if s.address.Equals(address) {
+			pubKey = s.key.PubKey()
+			break
+		}
+	}
+
+	txf, txn, err := c.buildTx(ctx, address, pubKey, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.encodeOfflineTx(txn.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	res.AccountNumber = txf.AccountNumber()
+	res.Sequence = txf.Sequence()
+
+	return res, nil
+}
+
+// SignOffline signs tx built by BuildUnsigned or `seid tx ... --generate-only` with the signer, using the given
+// account number and sequence. It does not access the network. unsignedTx is either JSON or protobuf encoded
+func (c *Client) SignOffline(ctx context.Context, signerName string, unsignedTx []byte, accountNumber, sequence uint64) (*OfflineTx, error) {
+	sgn, release, err := c.acquireSigner(signerName)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	decoded, err := c.decodeOfflineTx(unsignedTx)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := decoded.GetMsgs()[0].GetSigners()
+	if len(signers) == 0 || !signers[0].Equals(sgn.address) {
+		return nil, fmt.Errorf("tx is not sent from signer %s address %s", signerName, sgn.address)
+	}
+
+	txn, err := c.clientCtx.TxConfig.WrapTxBuilder(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("WrapTxBuilder: %w", err)
+	}
+
+	txf := c.factory(ctx).WithAccountNumber(accountNumber).WithSequence(sequence)
+	err = c.signTx(ctx, txf, sgn.key, txn)
+	if err != nil {
+		return nil, fmt.Errorf("Sign: %w", err)
+	}
+
+	res, err := c.encodeOfflineTx(txn.GetTx())
+	if err != nil {
+		return nil, err
+	}
+	res.AccountNumber = accountNumber
+	res.Sequence = sequence
+
+	return res, nil
+}
+
+// BroadcastSigned broadcasts tx signed by SignOffline or `seid tx sign`. signedTx is either JSON or protobuf encoded
+func (c *Client) BroadcastSigned(ctx context.Context, signedTx []byte) (resp *txtypes.BroadcastTxResponse, err error) {
+	decoded, err := c.decodeOfflineTx(signedTx)
+	if err != nil {
+		return resp, err
+	}
+
+	txBytes, err := c.clientCtx.TxConfig.TxEncoder()(decoded)
+	if err != nil {
+		return resp, fmt.Errorf("TxEncoder: %s", err)
+	}
+
+	return c.broadcastTxBytes(ctx, txBytes)
+}
+
+func (c *Client) encodeOfflineTx(tx sdktypes.Tx) (*OfflineTx, error) {
+	txJSON, err := c.clientCtx.TxConfig.TxJSONEncoder()(tx)
+	if err != nil {
+		return nil, fmt.Errorf("TxJSONEncoder: %w", err)
+	}
+
+	txBytes, err := c.clientCtx.TxConfig.TxEncoder()(tx)
+	if err != nil {
+		return nil, fmt.Errorf("TxEncoder: %w", err)
+	}
+
+	return &OfflineTx{JSON: txJSON, Bytes: txBytes}, nil
+}
+
+// decodeOfflineTx decodes JSON or protobuf encoded tx
+func (c *Client) decodeOfflineTx(bz []byte) (sdktypes.Tx, error) {
+	if len(bytes.TrimSpace(bz)) == 0 {
+		return nil, errors.New("empty tx")
+	}
+
+	var (
+		decoded sdktypes.Tx
+		err     error
+	)
+	// protobuf encoded tx starts with a field tag, which is never '{'. It may look like whitespace, so bz is not trimmed
+	if jsonTx := bytes.TrimSpace(bz); jsonTx[0] == '{' {
+		decoded, err = c.clientCtx.TxConfig.TxJSONDecoder()(jsonTx)
+	} else {
+		decoded, err = c.clientCtx.TxConfig.TxDecoder()(bz)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode tx: %w", err)
+	}
+	if len(decoded.GetMsgs()) == 0 {
+		return nil, errors.New("tx has no messages")
+	}
+
+	return decoded, nil
+}
@@ -0,0 +1,110 @@
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	txf "github.com/cosmos/cosmos-sdk/client/tx"
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"google.golang.org/grpc"
+	"gotest.tools/assert"
+)
+
+const testChainID = "test-chain"
+
+// stubTxService records broadcast txs
+type stubTxService struct {
+	txtypes.ServiceClient
+	broadcast [][]byte
+}
+
+func (s *stubTxService) BroadcastTx(_ context.Context, req *txtypes.BroadcastTxRequest, _ ...grpc.CallOption) (*txtypes.BroadcastTxResponse, error) {
+	s.broadcast = append(s.broadcast, req.TxBytes)
+	return &txtypes.BroadcastTxResponse{TxResponse: &sdktypes.TxResponse{TxHash: "HASH"}}, nil
+}
+
+// newTxClient creates client able to build and sign txs without network connections
+func newTxClient() *Client {
+	interfaceRegistry := codecTypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	banktypes.RegisterInterfaces(interfaceRegistry)
+	authz.RegisterInterfaces(interfaceRegistry)
+	legacyAmino := newLegacyAmino()
+	txConfig := newTxConfig(interfaceRegistry, legacyAmino)
+
+	c := newKeyringClient()
+	c.clientCtx = c.clientCtx.
+		WithTxConfig(txConfig).
+		WithLegacyAmino(legacyAmino).
+		WithInterfaceRegistry(interfaceRegistry).
+		WithChainID(testChainID)
+	c.txFactory = txf.Factory{}.
+		WithTxConfig(txConfig).
+		WithChainID(testChainID).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT).
+		WithGas(100_000).
+		WithGasPrices(DefaultGasPriceWithDenom)
+	c.txClient = &stubTxService{}
+
+	return c
+}
+
+func TestClient_SignOffline(t *testing.T) {
+	c := newTxClient()
+	ctx := context.Background()
+
+	addr, err := c.AddSigner("offline", testMnemonic1)
+	assert.NilError(t, err)
+	_, err = c.AddSigner("other", testMnemonic2)
+	assert.NilError(t, err)
+
+	from := sdktypes.MustAccAddressFromBech32(addr)
+	unsigned, err := c.txFactory.BuildUnsignedTx(banktypes.NewMsgSend(from, from, sdktypes.NewCoins(sdktypes.NewInt64Coin(DefaultDenom, 1))))
+	assert.NilError(t, err)
+	unsignedJSON, err := c.clientCtx.TxConfig.TxJSONEncoder()(unsigned.GetTx())
+	assert.NilError(t, err)
+
+	_, err = c.SignOffline(ctx, "other", unsignedJSON, 7, 3)
+	assert.ErrorContains(t, err, "is not sent from signer")
+
+	signed, err := c.SignOffline(ctx, "offline", unsignedJSON, 7, 3)
+	assert.NilError(t, err)
+	assert.Equal(t, signed.AccountNumber, uint64(7))
+	assert.Equal(t, signed.Sequence, uint64(3))
+
+	// the JSON and protobuf encodings hold the same signed tx
+	fromJSON, err := c.decodeOfflineTx(signed.JSON)
+	assert.NilError(t, err)
+	fromBytes, err := c.decodeOfflineTx(signed.Bytes)
+	assert.NilError(t, err)
+	fromJSONBytes, err := c.clientCtx.TxConfig.TxEncoder()(fromJSON)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fromJSONBytes, signed.Bytes)
+
+	sigTx := fromBytes.(authsigning.SigVerifiableTx)
+	sigs, err := sigTx.GetSignaturesV2()
+	assert.NilError(t, err)
+	assert.Equal(t, len(sigs), 1)
+	signBytes, err := c.clientCtx.TxConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_DIRECT, authsigning.SignerData{
+		ChainID:       testChainID,
+		AccountNumber: 7,
+		Sequence:      3,
+	}, fromBytes)
+	assert.NilError(t, err)
+	assert.Assert(t, sigs[0].PubKey.VerifySignature(signBytes, sigs[0].Data.(*signing.SingleSignatureData).Signature))
+
+	resp, err := c.BroadcastSigned(ctx, signed.JSON)
+	assert.NilError(t, err)
+	assert.Equal(t, resp.GetTxResponse().TxHash, "HASH")
+	assert.DeepEqual(t, c.txClient.(*stubTxService).broadcast, [][]byte{signed.Bytes})
+
+	_, err = c.BroadcastSigned(ctx, []byte(" "))
+	assert.ErrorContains(t, err, "empty tx")
+}
@@ -0,0 +1,215 @@
+package sdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	cosmosTypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Remote signer HTTP API:
+//
+//	GET  {base}/keys/{name}/pubkey -> {"pub_key": base64 compressed secp256k1 public key}
+//	POST {base}/keys/{name}/sign   {"sign_bytes": base64} -> {"signature": base64}
+//
+// Requests carry "Authorization: Bearer <token>" when the token is set
+const (
+	remoteSignerPubKeyPath = "/keys/%s/pubkey"
+	remoteSignerSignPath   = "/keys/%s/sign"
+)
+
+type remotePubKeyResponse struct {
+	PubKey []byte `json:"pub_key"`
+}
+
+type remoteSignRequest struct {
+	SignBytes []byte `json:"sign_bytes"`
+}
+
+type remoteSignResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+type remoteErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// RemoteSignerOptions configures RemoteSigner
+type RemoteSignerOptions struct {
+	// HTTPClient is used for requests, http.DefaultClient by default
+	HTTPClient *http.Client
+	// Token is sent as bearer token, empty means no authorization
+	Token string
+}
+
+// RemoteSigner is a Signer client of a separate signing service, so private keys do not live in the application process
+type RemoteSigner struct {
+	baseURL string
+	keyName string
+	opts    RemoteSignerOptions
+	pubKey  cryptotypes.PubKey
+}
+
+// NewRemoteSigner connects to the signing service at baseURL and fetches public key of the named key
+func NewRemoteSigner(ctx context.Context, baseURL, keyName string, opts RemoteSignerOptions) (*RemoteSigner, error) {
+	if baseURL == "" {
+		return nil, errors.New("empty base url")
+	}
+	if keyName == "" {
+		return nil, errors.New("empty key name")
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	s := &RemoteSigner{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		keyName: keyName,
+		opts:    opts,
+	}
+
+	var resp remotePubKeyResponse
+	err := s.do(ctx, http.MethodGet, remoteSignerPubKeyPath, nil, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("get public key: %w", err)
+	}
+	if len(resp.PubKey) != secp256k1.PubKeySize {
+		return nil, fmt.Errorf("invalid public key length %d, expected %d", len(resp.PubKey), secp256k1.PubKeySize)
+	}
+	s.pubKey = &secp256k1.PubKey{Key: resp.PubKey}
+
+	return s, nil
+}
+
+// PubKey returns public key of the remote key
+func (s *RemoteSigner) PubKey() cryptotypes.PubKey {
+	return s.pubKey
+}
+
+// Address returns address of the remote key
+func (s *RemoteSigner) Address() cosmosTypes.AccAddress {
+	return cosmosTypes.AccAddress(s.pubKey.Address())
+}
+
+// Sign sends the sign bytes to the signing service and verifies the returned signature
+func (s *RemoteSigner) Sign(ctx context.Context, signBytes []byte) ([]byte, error) {
+	var resp remoteSignResponse
+	err := s.do(ctx, http.MethodPost, remoteSignerSignPath, remoteSignRequest{SignBytes: signBytes}, &resp)
+	if err != nil {
+		return nil, fmt.Errorf("remote sign: %w", err)
+	}
+	if !s.pubKey.VerifySignature(signBytes, resp.Signature) {
+		return nil, errors.New("remote sign: invalid signature")
+	}
+
+	return resp.Signature, nil
+}
+
+func (s *RemoteSigner) do(ctx context.Context, method, pathFormat string, body, out any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("json.Marshal: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+fmt.Sprintf(pathFormat, url.PathEscape(s.keyName)), reqBody)
+	if err != nil {
+		return fmt.Errorf("NewRequest: %w", err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if s.opts.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.opts.Token)
+	}
+
+	resp, err := s.opts.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp remoteErrorResponse
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return fmt.Errorf("status %d: %s", resp.StatusCode, errResp.Error)
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(out)
+	if err != nil {
+		return fmt.Errorf("json.Decode: %w", err)
+	}
+
+	return nil
+}
+
+// NewRemoteSignerHandler returns http.Handler serving the remote signer API with the given signers by key name.
+// It is a local stand-in for a hardened signing service, e.g. for development and tests.
+// An empty token disables authorization
+func NewRemoteSignerHandler(signers map[string]Signer, token string) http.Handler {
+	mux := http.NewServeMux()
+
+	auth := func(next func(w http.ResponseWriter, r *http.Request, key Signer)) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if token != "" && subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+token)) != 1 {
+				writeRemoteSignerError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+				return
+			}
+
+			key, ok := signers[r.PathValue("name")]
+			if !ok {
+				writeRemoteSignerError(w, http.StatusNotFound, fmt.Errorf("key %s not found", r.PathValue("name")))
+				return
+			}
+
+			next(w, r, key)
+		}
+	}
+
+	mux.HandleFunc("GET "+fmt.Sprintf(remoteSignerPubKeyPath, "{name}"), auth(func(w http.ResponseWriter, _ *http.Request, key Signer) {
+		writeRemoteSignerJSON(w, remotePubKeyResponse{PubKey: key.PubKey().Bytes()})
+	}))
+
+	mux.HandleFunc("POST "+fmt.Sprintf(remoteSignerSignPath, "{name}"), auth(func(w http.ResponseWriter, r *http.Request, key Signer) {
+		var req remoteSignRequest
+		err := json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			writeRemoteSignerError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		sig, err := key.Sign(r.Context(), req.SignBytes)
+		if err != nil {
+			writeRemoteSignerError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeRemoteSignerJSON(w, remoteSignResponse{Signature: sig})
+	}))
+
+	return mux
+}
+
+func writeRemoteSignerJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeRemoteSignerError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(remoteErrorResponse{Error: err.Error()})
+}
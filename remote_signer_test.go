@@ -0,0 +1,105 @@
+package sdk
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	txf "github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/std"
+	cosmosTypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"gotest.tools/assert"
+)
+
+// newRemoteSignerServer serves a keyring key derived from testMnemonic1 as "remote"
+func newRemoteSignerServer(t *testing.T, token string) (*httptest.Server, Signer) {
+	kr := keyring.NewInMemory()
+	_, err := kr.NewAccount("remote", testMnemonic1, "", KeyOptions{}.hdPath(), hd.Secp256k1)
+	assert.NilError(t, err)
+
+	key, err := NewKeyringSigner(kr, "remote")
+	assert.NilError(t, err)
+
+	server := httptest.NewServer(NewRemoteSignerHandler(map[string]Signer{"remote": key}, token))
+	t.Cleanup(server.Close)
+
+	return server, key
+}
+
+func TestRemoteSigner(t *testing.T) {
+	server, key := newRemoteSignerServer(t, "secret")
+	ctx := context.Background()
+
+	_, err := NewRemoteSigner(ctx, server.URL, "remote", RemoteSignerOptions{Token: "wrong"})
+	assert.ErrorContains(t, err, "status 401")
+
+	_, err = NewRemoteSigner(ctx, server.URL, "missing", RemoteSignerOptions{Token: "secret"})
+	assert.ErrorContains(t, err, "status 404")
+
+	remote, err := NewRemoteSigner(ctx, server.URL, "remote", RemoteSignerOptions{Token: "secret"})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, remote.Address(), key.Address())
+
+	sig, err := remote.Sign(ctx, []byte("message"))
+	assert.NilError(t, err)
+	assert.Assert(t, key.PubKey().VerifySignature([]byte("message"), sig))
+}
+
+func TestClient_SignTx_ExternalSigner(t *testing.T) {
+	server, key := newRemoteSignerServer(t, "")
+	ctx := context.Background()
+
+	remote, err := NewRemoteSigner(ctx, server.URL, "remote", RemoteSignerOptions{})
+	assert.NilError(t, err)
+
+	interfaceRegistry := codecTypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	banktypes.RegisterInterfaces(interfaceRegistry)
+	txConfig := tx.NewTxConfig(codec.NewProtoCodec(interfaceRegistry), []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT})
+
+	c := newKeyringClient()
+	c.clientCtx = c.clientCtx.WithTxConfig(txConfig)
+
+	addr, err := c.AddExternalSigner("remote", remote)
+	assert.NilError(t, err)
+	assert.Equal(t, addr, key.Address().String())
+	evmAddr, err := EVMAddress(key.PubKey())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, c.ListSigners(), []SignerInfo{{Name: "remote", Address: addr, EVMAddress: evmAddr}})
+
+	factory := txf.Factory{}.
+		WithTxConfig(txConfig).
+		WithChainID("test-chain").
+		WithAccountNumber(7).
+		WithSequence(3).
+		WithSignMode(signing.SignMode_SIGN_MODE_DIRECT)
+	txn, err := factory.BuildUnsignedTx(banktypes.NewMsgSend(remote.Address(), remote.Address(), cosmosTypes.NewCoins(cosmosTypes.NewInt64Coin("usei", 1))))
+	assert.NilError(t, err)
+
+	sgn, err := c.getSigner("remote")
+	assert.NilError(t, err)
+	assert.NilError(t, c.signTx(ctx, factory, sgn.key, txn))
+
+	sigs, err := txn.GetTx().GetSignaturesV2()
+	assert.NilError(t, err)
+	assert.Equal(t, len(sigs), 1)
+
+	signBytes, err := txConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_DIRECT, authsigning.SignerData{
+		ChainID:       "test-chain",
+		AccountNumber: 7,
+		Sequence:      3,
+	}, txn.GetTx())
+	assert.NilError(t, err)
+	assert.Assert(t, key.PubKey().VerifySignature(signBytes, sigs[0].Data.(*signing.SingleSignatureData).Signature))
+
+	// removing an external signer does not touch the keyring
+	assert.NilError(t, c.RemoveSigner("remote"))
+}
@@ -0,0 +1,215 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+)
+
+const (
+	// DefaultRetryMaxAttempts is the default amount of query attempts, including the first one
+	DefaultRetryMaxAttempts = 3
+	// DefaultRetryInitialBackoff is the default delay before the first retry
+	DefaultRetryInitialBackoff = 200 * time.Millisecond
+	// DefaultRetryMaxBackoff is the default upper bound of the delay between retries
+	DefaultRetryMaxBackoff = 5 * time.Second
+
+	defaultRetryMultiplier = 2
+	defaultRetryJitter     = 0.2
+)
+
+var (
+	// errAttemptTimeout marks requests cancelled by RetryPolicy.AttemptTimeout
+	errAttemptTimeout = errors.New("attempt timeout")
+	// errRateLimited marks requests, which could not get rate limit token before the context deadline
+	errRateLimited = errors.New("rate limited")
+)
+
+// noRetryKey marks context of requests, which are failed over to another endpoint instead of being retried
+type noRetryKey struct{}
+
+type (
+	// RetryPolicy configures retries of queries failed with transient errors: unavailable or rate limiting node and
+	// attempt timeout. Failed queries are failed over to every endpoint first, then the last endpoint is retried with backoff,
+	// the delay grows exponentially from InitialBackoff up to MaxBackoff and is randomized by Jitter. Broadcasts are not retried
+	RetryPolicy struct {
+		// MaxAttempts including the first one, DefaultRetryMaxAttempts by default. 1 disables retries
+		MaxAttempts int
+		// InitialBackoff is the delay before the first retry, DefaultRetryInitialBackoff by default
+		InitialBackoff time.Duration
+		// MaxBackoff caps the delay, DefaultRetryMaxBackoff by default
+		MaxBackoff time.Duration
+		// Multiplier of the delay after every retry, 2 by default
+		Multiplier float64
+		// Jitter is the fraction of the delay randomized in both directions, 0.2 by default
+		Jitter float64
+		// AttemptTimeout is the deadline of a single request to an endpoint, timed out requests are retried. Not limited by default
+		AttemptTimeout time.Duration
+	}
+
+	// RateLimit limits requests sent to every endpoint with a token bucket. Requests wait for a token
+	// instead of failing, so public node limits are not hit
+	RateLimit struct {
+		// RPS is the sustained amount of requests per second, unlimited when zero
+		RPS float64
+		// Burst is the amount of requests sent at once, the ceiling of RPS by default
+		Burst int
+	}
+)
+
+func (r RetryPolicy) validate() error {
+	switch {
+	case r.MaxAttempts < 0:
+		return errors.New("negative MaxAttempts")
+	case r.InitialBackoff < 0 || r.MaxBackoff < 0 || r.AttemptTimeout < 0:
+		return errors.New("negative duration")
+	case r.Multiplier != 0 && r.Multiplier < 1:
+		return fmt.Errorf("Multiplier %v is less than 1", r.Multiplier)
+	case r.Jitter < 0 || r.Jitter > 1:
+		return fmt.Errorf("Jitter %v is out of [0, 1]", r.Jitter)
+	}
+
+	return nil
+}
+
+func (r RetryPolicy) withDefaults() RetryPolicy {
+	if r.MaxAttempts == 0 {
+		r.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if r.InitialBackoff == 0 {
+		r.InitialBackoff = DefaultRetryInitialBackoff
+	}
+	if r.MaxBackoff == 0 {
+		r.MaxBackoff = DefaultRetryMaxBackoff
+	}
+	if r.Multiplier == 0 {
+		r.Multiplier = defaultRetryMultiplier
+	}
+	if r.Jitter == 0 {
+		r.Jitter = defaultRetryJitter
+	}
+
+	return r
+}
+
+// backoff returns delay before the retry, retries are counted from 1
+func (r RetryPolicy) backoff(retry int) time.Duration {
+	delay := float64(r.InitialBackoff) * math.Pow(r.Multiplier, float64(retry-1))
+	delay = min(delay, float64(r.MaxBackoff))
+	delay += delay * r.Jitter * (2*rand.Float64() - 1)
+
+	return time.Duration(delay)
+}
+
+func (l RateLimit) validate() error {
+	if l.RPS < 0 || l.Burst < 0 {
+		return errors.New("negative rate limit")
+	}
+
+	return nil
+}
+
+// limiter returns token bucket of the limit, nil when requests are not limited
+func (l RateLimit) limiter() *rate.Limiter {
+	if l.RPS == 0 {
+		return nil
+	}
+
+	burst := l.Burst
+	if burst == 0 {
+		burst = int(math.Ceil(l.RPS))
+	}
+
+	return rate.NewLimiter(rate.Limit(l.RPS), burst)
+}
+
+// withoutRetry disables retries of the request, it is failed over to another endpoint instead
+func withoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+// endpointInterceptor retries, rate limits and times out requests to a single endpoint. It intercepts gRPC requests
+// of the endpoint connection and RPC requests of poolRPC, so both share the rate limit of the endpoint
+func endpointInterceptor(cfg Config, logger *slog.Logger) grpc.UnaryClientInterceptor {
+	return chainUnaryInterceptors(retryInterceptor(cfg.Retry.withDefaults(), logger), limitInterceptor(cfg.RateLimit.limiter(), cfg.Retry.AttemptTimeout))
+}
+
+// retryInterceptor retries requests failed with transient errors with backoff. Broadcasts and requests of withoutRetry contexts are not retried
+func retryInterceptor(policy RetryPolicy, logger *slog.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		attempts := policy.MaxAttempts
+		if method == broadcastTxMethod || ctx.Value(noRetryKey{}) != nil {
+			attempts = 1
+		}
+
+		var err error
+		for attempt := range attempts {
+			if attempt > 0 {
+				backoff := policy.backoff(attempt)
+				logger.WarnContext(ctx, "request failed, retrying", "method", method, "attempt", attempt+1, "backoff", backoff, "error", err)
+				select {
+				case <-ctx.Done():
+					return err
+				case <-time.After(backoff):
+				}
+			}
+
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || ctx.Err() != nil {
+				return err
+			}
+			if transient, _ := classifyEndpointErr(err); !transient {
+				return err
+			}
+		}
+
+		return err
+	}
+}
+
+// limitInterceptor waits for a token of the limiter, which may be nil, and calls the endpoint with the attempt timeout
+func limitInterceptor(limiter *rate.Limiter, attemptTimeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if limiter != nil {
+			err := limiter.Wait(ctx)
+			if err != nil {
+				return fmt.Errorf("%w: %w", errRateLimited, err)
+			}
+		}
+
+		if attemptTimeout == 0 {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		attemptCtx, cancel := context.WithTimeout(ctx, attemptTimeout)
+		defer cancel()
+
+		err := invoker(attemptCtx, method, req, reply, cc, opts...)
+		if err != nil && attemptCtx.Err() != nil && ctx.Err() == nil {
+			return fmt.Errorf("%w: %w", errAttemptTimeout, err)
+		}
+
+		return err
+	}
+}
+
+// chainUnaryInterceptors chains the interceptors into one, the first one is the outermost, as grpc.WithChainUnaryInterceptor does
+func chainUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, next := interceptors[i], invoker
+			invoker = func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				return interceptor(ctx, method, req, reply, cc, next, opts...)
+			}
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
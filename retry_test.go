@@ -0,0 +1,176 @@
+package sdk
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+// fastRetry is retry policy without noticeable delays
+var fastRetry = RetryPolicy{InitialBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+func TestEndpointPool_Retry(t *testing.T) {
+	conn := &fakeConn{failures: 2}
+	txClient := txtypes.NewServiceClient(newFakeConnPool(Config{Retry: fastRetry}, conn))
+
+	_, err := txClient.GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.NilError(t, err)
+	assert.Equal(t, conn.callCount(), 3)
+
+	conn = &fakeConn{failures: 3}
+	txClient = txtypes.NewServiceClient(newFakeConnPool(Config{Retry: fastRetry}, conn))
+
+	_, err = txClient.GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.Equal(t, status.Code(err), codes.ResourceExhausted)
+	assert.Equal(t, conn.callCount(), DefaultRetryMaxAttempts)
+
+	// the request is failed over to every endpoint before the last one is retried
+	conns := []*fakeConn{{failures: 1}, {failures: 1}}
+	txClient = txtypes.NewServiceClient(newFakeConnPool(Config{Retry: fastRetry}, conns...))
+
+	_, err = txClient.GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.NilError(t, err)
+	assert.Equal(t, conns[0].callCount()+conns[1].callCount(), 3)
+}
+
+func TestEndpointInterceptor_GRPCConn(t *testing.T) {
+	var calls atomic.Int32
+	srv := grpc.NewServer(grpc.UnknownServiceHandler(func(any, grpc.ServerStream) error {
+		if calls.Add(1) <= 2 {
+			return status.Error(codes.ResourceExhausted, "too many requests")
+		}
+		return status.Error(codes.Unimplemented, "unknown service")
+	}))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NilError(t, err)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	cfg := Config{InsecureGRPC: true, Retry: fastRetry}
+	conn, err := getGRPCConn(cfg, Endpoint{GRPCHost: lis.Addr().String()}, grpc.WithChainUnaryInterceptor(endpointInterceptor(cfg, newLogger(cfg))))
+	assert.NilError(t, err)
+	defer conn.Close()
+
+	_, err = txtypes.NewServiceClient(conn).GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.Equal(t, status.Code(err), codes.Unimplemented)
+	assert.Equal(t, calls.Load(), int32(3))
+
+	// broadcasts are not retried
+	calls.Store(0)
+	_, err = txtypes.NewServiceClient(conn).BroadcastTx(context.Background(), &txtypes.BroadcastTxRequest{})
+	assert.Equal(t, status.Code(err), codes.ResourceExhausted)
+	assert.Equal(t, calls.Load(), int32(1))
+}
+
+func TestEndpointPool_RetryDisabled(t *testing.T) {
+	conn := &fakeConn{failures: 1}
+	retry := fastRetry
+	retry.MaxAttempts = 1
+	txClient := txtypes.NewServiceClient(newFakeConnPool(Config{Retry: retry}, conn))
+
+	_, err := txClient.GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.Equal(t, status.Code(err), codes.ResourceExhausted)
+	assert.Equal(t, conn.callCount(), 1)
+}
+
+func TestEndpointPool_BroadcastNotRetried(t *testing.T) {
+	conn := &fakeConn{failures: 1}
+	txClient := txtypes.NewServiceClient(newFakeConnPool(Config{Retry: fastRetry}, conn))
+
+	_, err := txClient.BroadcastTx(context.Background(), &txtypes.BroadcastTxRequest{})
+	assert.Equal(t, status.Code(err), codes.ResourceExhausted)
+	assert.Equal(t, conn.callCount(), 1)
+}
+
+func TestEndpointPool_AttemptTimeout(t *testing.T) {
+	conn := &fakeConn{hangs: 1}
+	retry := fastRetry
+	retry.AttemptTimeout = 20 * time.Millisecond
+	txClient := txtypes.NewServiceClient(newFakeConnPool(Config{Retry: retry}, conn))
+
+	_, err := txClient.GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.NilError(t, err)
+	assert.Equal(t, conn.callCount(), 2)
+}
+
+func TestEndpointPool_CallTimeout(t *testing.T) {
+	conn := &fakeConn{hangs: 1}
+	txClient := txtypes.NewServiceClient(newFakeConnPool(Config{Retry: fastRetry, CallTimeout: 20 * time.Millisecond}, conn))
+
+	start := time.Now()
+	_, err := txClient.GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+	assert.Equal(t, status.Code(err), codes.DeadlineExceeded)
+	assert.Assert(t, time.Since(start) < time.Second)
+	assert.Equal(t, conn.callCount(), 1)
+}
+
+func TestEndpointPool_RPCRetry(t *testing.T) {
+	stub := newStubRPC(t, 1)
+	stub.failures = 2
+
+	rpc, err := getRPCClient(stub.server.URL, nil)
+	assert.NilError(t, err)
+	cfg := Config{Retry: fastRetry}
+	e := newEndpoint(Endpoint{RPCHost: stub.server.URL}, nil, rpc)
+	e.intercept = endpointInterceptor(cfg, newLogger(cfg))
+	pool := newEndpointPool([]*endpoint{e}, cfg, newLogger(Config{}))
+
+	page, perPage := 1, 10
+	res, err := newPoolRPC(pool).TxSearch(context.Background(), "tx.height>=1 AND tx.height<=1", false, &page, &perPage, "asc")
+	assert.NilError(t, err)
+	assert.Equal(t, res.TotalCount, 1)
+	assert.Equal(t, stub.searchCount(), 3)
+}
+
+func TestEndpointPool_RateLimit(t *testing.T) {
+	conn := &fakeConn{}
+	txClient := txtypes.NewServiceClient(newFakeConnPool(Config{RateLimit: RateLimit{RPS: 50, Burst: 1}}, conn))
+
+	start := time.Now()
+	for range 6 {
+		_, err := txClient.GetTx(context.Background(), &txtypes.GetTxRequest{Hash: "00"})
+		assert.NilError(t, err)
+	}
+	assert.Assert(t, time.Since(start) >= 90*time.Millisecond)
+
+	// the request fails when no token is available before the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err := txClient.GetTx(ctx, &txtypes.GetTxRequest{Hash: "00"})
+	assert.ErrorContains(t, err, "rate limited")
+	assert.Equal(t, conn.callCount(), 6)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	retry := RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}.withDefaults()
+
+	for range 100 {
+		assert.Assert(t, retry.backoff(1) >= 80*time.Millisecond && retry.backoff(1) <= 120*time.Millisecond)
+		assert.Assert(t, retry.backoff(2) >= 160*time.Millisecond && retry.backoff(2) <= 240*time.Millisecond)
+		assert.Assert(t, retry.backoff(10) >= 800*time.Millisecond && retry.backoff(10) <= 1200*time.Millisecond)
+	}
+}
+
+func TestConfig_ValidateRetry(t *testing.T) {
+	cfg := Config{ChainID: testChainID, GRPCHost: "localhost:9090", RPCHost: "http://localhost:26657"}
+	assert.NilError(t, cfg.Validate())
+
+	cfg.Retry.Jitter = 2
+	assert.ErrorContains(t, cfg.Validate(), "Retry: Jitter 2 is out of [0, 1]")
+
+	cfg.Retry.Jitter = 0
+	cfg.RateLimit.RPS = -1
+	assert.ErrorContains(t, cfg.Validate(), "RateLimit: negative rate limit")
+
+	cfg.RateLimit.RPS = 0
+	cfg.CallTimeout = -time.Second
+	assert.ErrorContains(t, cfg.Validate(), "negative CallTimeout")
+}
@@ -0,0 +1,81 @@
+package sdk
+
+import (
+	"fmt"
+	"net/http"
+
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+)
+
+// httpStatusError is returned for RPC responses of proxies in front of the node, e.g. rate limiting or bad gateway,
+// as the RPC client reports them as malformed JSON
+type httpStatusError struct {
+	StatusCode int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("unexpected HTTP status %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+// statusTransport turns HTTP statuses of rate limiting and unavailable nodes into httpStatusError.
+// The node itself answers errors with 200 OK, so JSON-RPC errors are not affected
+type statusTransport struct {
+	base http.RoundTripper
+}
+
+func (t statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		resp.Body.Close()
+		return nil, &httpStatusError{StatusCode: resp.StatusCode}
+	}
+
+	return resp, nil
+}
+
+// rpcClient is RPC client of the node, which closes idle HTTP connections on Close
+type rpcClient struct {
+	*rpchttp.HTTP
+	httpClient *http.Client
+}
+
+// Close closes idle HTTP connections, the client does not open websocket connections
+func (c *rpcClient) Close() error {
+	c.httpClient.CloseIdleConnections()
+
+	return nil
+}
+
+// getRPCClient creates RPC client of the node with the auth, which reports rate limiting and unavailable proxies as httpStatusError
+func getRPCClient(rpcHost string, auth *AuthConfig) (*rpcClient, error) {
+	httpClient, err := jsonrpcclient.DefaultHTTPClient(rpcHost)
+	if err != nil {
+		return nil, fmt.Errorf("DefaultHTTPClient: %w", err)
+	}
+
+	transport := httpClient.Transport
+	tlsConfig, err := auth.tlsConfig()
+	if err != nil {
+		return nil, fmt.Errorf("tlsConfig: %w", err)
+	}
+	if t, ok := transport.(*http.Transport); ok && tlsConfig != nil {
+		t.TLSClientConfig = tlsConfig
+	}
+	if headers := auth.headers(); len(headers) > 0 {
+		transport = headerTransport{base: transport, headers: headers}
+	}
+	httpClient.Transport = statusTransport{base: transport}
+
+	rpc, err := rpchttp.NewWithClient(rpcHost, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rpcClient{HTTP: rpc, httpClient: httpClient}, nil
+}
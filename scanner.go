@@ -0,0 +1,415 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultScanShardSize     = 10_000
+	defaultScanWorkers       = 4
+	defaultScanMaxRetries    = 5
+	defaultScanRetryInterval = time.Second
+	// maxScanRetryInterval caps the doubled delay between shard refetches, unless RetryInterval is longer
+	maxScanRetryInterval = 30 * time.Second
+
+	// minScanPageSize is the lowest page size the throttle lowers to
+	minScanPageSize = 10
+
+	// scanSuccessStreak is the number of successful shard fetches after which throttled limits are raised again
+	scanSuccessStreak = 4
+)
+
+// ScanConfig configures parallel historical tx scanning
+type ScanConfig struct {
+	// ContractAddress restricts the scan to txs emitting wasm events of the contract. Optional
+	ContractAddress string
+	// Query is an additional TxSearch condition joined with AND. Optional
+	Query string
+
+	// HeightFrom and HeightTo bound the scan, both are inclusive
+	HeightFrom int64
+	HeightTo   int64
+
+	// ShardSize is the amount of heights fetched by a single worker task
+	ShardSize int64
+	// Workers is the maximum amount of shards fetched concurrently
+	Workers int
+	// PageSize is the initial TxSearch page size, it is lowered while the node returns errors.
+	// DefaultTxSearchPageSize is used by default and is the maximum
+	PageSize int
+	// MaxRetries is the amount of refetches of a failed shard before the scan is aborted.
+	// Zero means 5 retries, NoScanRetries disables retries
+	MaxRetries int
+	// RetryInterval is the base delay between shard refetches, it doubles on every attempt up to 30 seconds
+	RetryInterval time.Duration
+
+	// OnProgress is called after every shard delivered to the callback. Optional
+	OnProgress func(ScanProgress)
+}
+
+// NoScanRetries is the ScanConfig.MaxRetries value aborting the scan on the first failed shard fetch
+const NoScanRetries = -1
+
+// ScanProgress describes the state of a running scan
+type ScanProgress struct {
+	ShardsDone  int
+	ShardsTotal int
+	// Height is the last height covered by delivered shards
+	Height int64
+	// Txs is the amount of txs delivered to the callback so far
+	Txs int
+	// Workers and PageSize are the current adaptive limits
+	Workers  int
+	PageSize int
+}
+
+// Validate validates scan config and fills defaults
+func (cfg *ScanConfig) Validate() error {
+	err := HeightRange{From: cfg.HeightFrom, To: cfg.HeightTo}.Validate()
+	if err != nil {
+		return err
+	}
+	if cfg.ContractAddress == "" && cfg.Query == "" {
+		return errors.New("empty ContractAddress and Query")
+	}
+
+	if cfg.ShardSize <= 0 {
+		cfg.ShardSize = defaultScanShardSize
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultScanWorkers
+	}
+	cfg.PageSize = txSearchPageSize(cfg.PageSize)
+	if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	} else if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultScanMaxRetries
+	}
+	if cfg.RetryInterval <= 0 {
+		cfg.RetryInterval = defaultScanRetryInterval
+	}
+
+	return nil
+}
+
+// query builds TxSearch query for heights in [from, to]
+func (cfg *ScanConfig) query(from, to int64) string {
+	conditions := []string{fmt.Sprintf("tx.height>=%d AND tx.height<=%d", from, to)}
+	if cfg.ContractAddress != "" {
+		conditions = append(conditions, fmt.Sprintf("wasm._contract_address CONTAINS '%s'", cfg.ContractAddress))
+	}
+	if cfg.Query != "" {
+		conditions = append(conditions, cfg.Query)
+	}
+
+	return strings.Join(conditions, " AND ")
+}
+
+type shardResult struct {
+	index int
+	txs   []*coretypes.ResultTx
+	err   error
+}
+
+// ScanTxs splits the height range into shards fetched by a bounded worker pool and delivers txs to the callback
+// strictly in (height, index) order. Concurrency and page size are lowered when the node returns errors
+// and restored once requests succeed again.
+func (c *Client) ScanTxs(ctx context.Context, cfg ScanConfig, handle func(ctx context.Context, tx *coretypes.ResultTx) error) error {
+	err := cfg.Validate()
+	if err != nil {
+		return err
+	}
+
+	tendermintNode, err := c.clientCtx.GetNode()
+	if err != nil {
+		return fmt.Errorf("clientCtx.GetNode: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	shardsTotal := int((cfg.HeightTo - cfg.HeightFrom + cfg.ShardSize) / cfg.ShardSize)
+	shardBounds := func(index int) (int64, int64) {
+		from := cfg.HeightFrom + int64(index)*cfg.ShardSize
+		return from, min(from+cfg.ShardSize-1, cfg.HeightTo)
+	}
+
+	throttle := newScanThrottle(cfg.Workers, cfg.PageSize)
+
+	// window bounds the amount of fetched but not yet delivered shards
+	window := make(chan struct{}, cfg.Workers*2)
+	tasks := make(chan int)
+	results := make(chan shardResult)
+
+	go func() {
+		defer close(tasks)
+		for i := range shardsTotal {
+			select {
+			case window <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case tasks <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for range cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range tasks {
+				from, to := shardBounds(index)
+				txs, err := fetchShard(ctx, tendermintNode, &cfg, throttle, cfg.query(from, to))
+				select {
+				case results <- shardResult{index: index, txs: txs, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	progress := ScanProgress{ShardsTotal: shardsTotal}
+	pending := make(map[int][]*coretypes.ResultTx)
+	for res := range results {
+		if res.err != nil {
+			cancel()
+			return fmt.Errorf("shard %d: %w", res.index, res.err)
+		}
+		pending[res.index] = res.txs
+
+		for {
+			txs, ok := pending[progress.ShardsDone]
+			if !ok {
+				break
+			}
+			delete(pending, progress.ShardsDone)
+
+			for _, tx := range txs {
+				err = handle(ctx, tx)
+				if err != nil {
+					cancel()
+					return err
+				}
+			}
+
+			_, progress.Height = shardBounds(progress.ShardsDone)
+			progress.ShardsDone++
+			progress.Txs += len(txs)
+			progress.Workers, progress.PageSize = throttle.limits()
+			<-window
+
+			if cfg.OnProgress != nil {
+				cfg.OnProgress(progress)
+			}
+		}
+
+		if progress.ShardsDone == shardsTotal {
+			return nil
+		}
+	}
+
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	return fmt.Errorf("scan stopped after %d of %d shards", progress.ShardsDone, shardsTotal)
+}
+
+// fetchShard fetches all pages of a shard query, refetching the whole shard with adapted limits on failure
+func fetchShard(ctx context.Context, node txSearcher, cfg *ScanConfig, throttle *scanThrottle, query string) (txs []*coretypes.ResultTx, err error) {
+	for attempt := range cfg.MaxRetries + 1 {
+		if attempt > 0 {
+			select {
+			case <-time.After(scanRetryDelay(cfg.RetryInterval, attempt, isRateLimitErr(err))):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		var pageSize int
+		pageSize, err = throttle.acquire(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		txs, err = fetchTxPages(ctx, node, query, pageSize, false)
+		throttle.release(err)
+		if err == nil {
+			return txs, nil
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+	}
+
+	return nil, fmt.Errorf("after %d retries: %w", cfg.MaxRetries, err)
+}
+
+// txSearcher is the part of the RPC client used for tx searching
+type txSearcher interface {
+	TxSearch(ctx context.Context, query string, prove bool, page, perPage *int, orderBy string) (*coretypes.ResultTxSearch, error)
+}
+
+// fetchTxPages fetches every page of the query
+func fetchTxPages(ctx context.Context, node txSearcher, query string, pageSize int, prove bool) (txs []*coretypes.ResultTx, err error) {
+	err = searchTxPages(ctx, node, query, pageSize, prove, func(_ int, resp *coretypes.ResultTxSearch) error {
+		txs = append(txs, resp.Txs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return txs, nil
+}
+
+// searchTxPages passes pages of the query to the callback until TotalCount txs are received
+func searchTxPages(ctx context.Context, node txSearcher, query string, pageSize int, prove bool, handlePage func(page int, resp *coretypes.ResultTxSearch) error) error {
+	fetched := 0
+	for page := 1; ; page++ {
+		resp, err := node.TxSearch(ctx, query, prove, &page, &pageSize, "asc")
+		if err != nil {
+			return fmt.Errorf("TxSearch: %w", err)
+		}
+
+		err = handlePage(page, resp)
+		if err != nil {
+			return err
+		}
+
+		fetched += len(resp.Txs)
+		if len(resp.Txs) == 0 || fetched >= resp.TotalCount {
+			return nil
+		}
+	}
+}
+
+// txSearchPageSize returns the page size limited to DefaultTxSearchPageSize, which is used for non-positive sizes
+func txSearchPageSize(size int) int {
+	if size <= 0 || size > DefaultTxSearchPageSize {
+		return DefaultTxSearchPageSize
+	}
+
+	return size
+}
+
+// scanRetryDelay returns delay before the refetch, retries are counted from 1. The interval doubles on every retry
+// and once more for rate limited requests, it is capped by maxScanRetryInterval
+func scanRetryDelay(interval time.Duration, retry int, rateLimited bool) time.Duration {
+	exp := float64(retry - 1)
+	if rateLimited {
+		exp++
+	}
+	delay := float64(interval) * math.Pow(2, exp)
+
+	return time.Duration(min(delay, float64(max(interval, maxScanRetryInterval))))
+}
+
+// isRateLimitErr reports whether the node or its proxy rejected a request because of rate limiting:
+// HTTP 429 of the proxy or gRPC ResourceExhausted
+func isRateLimitErr(err error) bool {
+	var httpErr *httpStatusError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Code() == codes.ResourceExhausted
+	}
+
+	return false
+}
+
+// scanThrottle adapts concurrency and page size of a scan to node errors
+type scanThrottle struct {
+	mu sync.Mutex
+
+	maxWorkers  int
+	maxPageSize int
+	minPageSize int
+
+	workers  int
+	pageSize int
+	active   int
+	streak   int
+}
+
+func newScanThrottle(workers, pageSize int) *scanThrottle {
+	return &scanThrottle{
+		maxWorkers:  workers,
+		maxPageSize: pageSize,
+		minPageSize: min(minScanPageSize, pageSize),
+		workers:     workers,
+		pageSize:    pageSize,
+	}
+}
+
+// acquire waits for a free worker slot and returns the page size to use
+func (t *scanThrottle) acquire(ctx context.Context) (int, error) {
+	for {
+		t.mu.Lock()
+		if t.active < t.workers {
+			t.active++
+			pageSize := t.pageSize
+			t.mu.Unlock()
+
+			return pageSize, nil
+		}
+		t.mu.Unlock()
+
+		select {
+		case <-time.After(50 * time.Millisecond):
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}
+}
+
+// release frees a worker slot and adapts limits to the result of the request
+func (t *scanThrottle) release(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.active--
+	if err != nil {
+		t.streak = 0
+		t.workers = max(1, t.workers/2)
+		t.pageSize = max(t.minPageSize, t.pageSize/2)
+
+		return
+	}
+
+	t.streak++
+	if t.streak >= scanSuccessStreak {
+		t.streak = 0
+		t.workers = min(t.maxWorkers, t.workers+1)
+		t.pageSize = min(t.maxPageSize, t.pageSize*2)
+	}
+}
+
+func (t *scanThrottle) limits() (int, int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.workers, t.pageSize
+}
@@ -0,0 +1,124 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func TestClient_ScanTxs_Ordered(t *testing.T) {
+	var heights []int64
+	for h := int64(1); h <= 50; h++ {
+		heights = append(heights, h, h)
+	}
+	stub := newStubRPC(t, heights...)
+	stub.failures = 3
+	c := newStubClient(t, stub)
+
+	type position struct {
+		height int64
+		index  uint32
+	}
+	var (
+		got      []position
+		progress []ScanProgress
+	)
+	err := c.ScanTxs(context.Background(), ScanConfig{
+		ContractAddress: testContractAddress,
+		HeightFrom:      1,
+		HeightTo:        50,
+		ShardSize:       3,
+		Workers:         4,
+		PageSize:        10,
+		RetryInterval:   time.Millisecond,
+		OnProgress:      func(p ScanProgress) { progress = append(progress, p) },
+	}, func(_ context.Context, tx *coretypes.ResultTx) error {
+		got = append(got, position{height: tx.Height, index: tx.Index})
+		return nil
+	})
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(got), len(heights))
+	for i := 1; i < len(got); i++ {
+		prev, cur := got[i-1], got[i]
+		assert.Assert(t, prev.height < cur.height || (prev.height == cur.height && prev.index < cur.index), "tx %d out of order", i)
+	}
+
+	assert.Equal(t, len(progress), 17)
+	last := progress[len(progress)-1]
+	assert.Equal(t, last.ShardsDone, last.ShardsTotal)
+	assert.Equal(t, last.Height, int64(50))
+	assert.Equal(t, last.Txs, len(heights))
+}
+
+func TestClient_ScanTxs_RetriesExhausted(t *testing.T) {
+	stub := newStubRPC(t, 1, 2, 3)
+	stub.failures = 100
+	c := newStubClient(t, stub)
+
+	err := c.ScanTxs(context.Background(), ScanConfig{
+		ContractAddress: testContractAddress,
+		HeightFrom:      1,
+		HeightTo:        3,
+		MaxRetries:      2,
+		RetryInterval:   time.Millisecond,
+	}, func(context.Context, *coretypes.ResultTx) error { return nil })
+	assert.ErrorContains(t, err, "after 2 retries")
+}
+
+func TestScanConfig_Validate(t *testing.T) {
+	cfg := ScanConfig{ContractAddress: testContractAddress, HeightFrom: 1, HeightTo: 10}
+	assert.NilError(t, cfg.Validate())
+	assert.Equal(t, cfg.MaxRetries, defaultScanMaxRetries)
+	assert.Equal(t, cfg.PageSize, DefaultTxSearchPageSize)
+
+	cfg = ScanConfig{ContractAddress: testContractAddress, HeightFrom: 1, HeightTo: 10, MaxRetries: NoScanRetries, PageSize: 500}
+	assert.NilError(t, cfg.Validate())
+	assert.Equal(t, cfg.MaxRetries, 0)
+	assert.Equal(t, cfg.PageSize, DefaultTxSearchPageSize)
+
+	cfg = ScanConfig{ContractAddress: testContractAddress, HeightFrom: 1, HeightTo: 10, PageSize: 5}
+	assert.NilError(t, cfg.Validate())
+	assert.Equal(t, cfg.PageSize, 5)
+
+	cfg = ScanConfig{ContractAddress: testContractAddress, HeightFrom: 10, HeightTo: 1}
+	assert.ErrorContains(t, cfg.Validate(), "empty height range")
+}
+
+func TestClient_ScanTxs_NoRetries(t *testing.T) {
+	stub := newStubRPC(t, 1, 2, 3)
+	stub.failures = 1
+	c := newStubClient(t, stub)
+
+	err := c.ScanTxs(context.Background(), ScanConfig{
+		ContractAddress: testContractAddress,
+		HeightFrom:      1,
+		HeightTo:        3,
+		MaxRetries:      NoScanRetries,
+	}, func(context.Context, *coretypes.ResultTx) error { return nil })
+	assert.ErrorContains(t, err, "after 0 retries")
+}
+
+func TestIsRateLimitErr(t *testing.T) {
+	assert.Assert(t, isRateLimitErr(fmt.Errorf("search txs: %w", &httpStatusError{StatusCode: 429})))
+	assert.Assert(t, isRateLimitErr(status.Error(codes.ResourceExhausted, "slow down")))
+	assert.Assert(t, !isRateLimitErr(&httpStatusError{StatusCode: 503}))
+	assert.Assert(t, !isRateLimitErr(status.Error(codes.Unavailable, "429")))
+	assert.Assert(t, !isRateLimitErr(errors.New("height 104290 is not available")))
+	assert.Assert(t, !isRateLimitErr(nil))
+}
+
+func TestScanRetryDelay(t *testing.T) {
+	assert.Equal(t, scanRetryDelay(time.Second, 1, false), time.Second)
+	assert.Equal(t, scanRetryDelay(time.Second, 3, false), 4*time.Second)
+	assert.Equal(t, scanRetryDelay(time.Second, 3, true), 8*time.Second)
+	assert.Equal(t, scanRetryDelay(time.Second, 100, true), maxScanRetryInterval)
+	assert.Equal(t, scanRetryDelay(time.Minute, 5, false), time.Minute)
+}
@@ -0,0 +1,732 @@
+package sdktest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+
+	sdk "github.com/spell-club/sei-sdk"
+)
+
+// txEntry is a delivered tx
+type txEntry struct {
+	result *coretypes.ResultTx
+	hash   string
+}
+
+// snapshot is the state restored when a tx fails
+type snapshot struct {
+	balances       map[string]sdktypes.Coins
+	contracts      map[string]*Contract
+	nextInstanceID uint64
+}
+
+func (f *Fake) Execute(ctx context.Context, signerName, contractAddress, msg string) (*txtypes.BroadcastTxResponse, error) {
+	if msg == "" {
+		return nil, errors.New("message is empty")
+	}
+	err := f.check("Execute")
+	if err != nil {
+		return nil, err
+	}
+
+	return f.broadcastMsgs(ctx, signerName, func(sender string) sdktypes.Msg {
+		return &wasmtypes.MsgExecuteContract{Sender: sender, Contract: contractAddress, Msg: []byte(msg)}
+	})
+}
+
+func (f *Fake) ExecuteJSON(ctx context.Context, signerName, contractAddress string, msg interface{}) (*txtypes.BroadcastTxResponse, error) {
+	marshalledMsg, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Execute(ctx, signerName, contractAddress, string(marshalledMsg))
+}
+
+func (f *Fake) Instantiate(ctx context.Context, signerName string, codeID uint64, label, instantiateMsg string, funds []sdktypes.Coin) (*txtypes.BroadcastTxResponse, error) {
+	if instantiateMsg == "" {
+		return nil, errors.New("message code is empty")
+	}
+	if label == "" {
+		return nil, errors.New("label is empty")
+	}
+	err := f.check("Instantiate")
+	if err != nil {
+		return nil, err
+	}
+
+	return f.broadcastMsgs(ctx, signerName, func(sender string) sdktypes.Msg {
+		return &wasmtypes.MsgInstantiateContract{Sender: sender, Admin: sender, Label: label, CodeID: codeID, Msg: []byte(instantiateMsg), Funds: funds}
+	})
+}
+
+func (f *Fake) InstantiateJSON(ctx context.Context, signerName string, codeID uint64, label string, instantiateMsg interface{}, funds []sdktypes.Coin) (*txtypes.BroadcastTxResponse, error) {
+	marshalledMsg, err := json.Marshal(instantiateMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.Instantiate(ctx, signerName, codeID, label, string(marshalledMsg), funds)
+}
+
+// BuildUnsigned builds unsigned tx with GasLimit and the account number and sequence of the fake account
+func (f *Fake) BuildUnsigned(_ context.Context, fromAddress string, msgs ...sdktypes.Msg) (*sdk.OfflineTx, error) {
+	err := f.check("BuildUnsigned")
+	if err != nil {
+		return nil, err
+	}
+
+	return f.buildUnsigned(fromAddress, msgs...)
+}
+
+func (f *Fake) SignOffline(ctx context.Context, signerName string, unsignedTx []byte, accountNumber, sequence uint64) (*sdk.OfflineTx, error) {
+	return f.keys.SignOffline(ctx, signerName, unsignedTx, accountNumber, sequence)
+}
+
+// BroadcastSigned delivers the signed tx. Unlike Execute and Instantiate, txs failed by the handlers are committed
+// with non-zero code, as there is no simulation before the broadcast
+func (f *Fake) BroadcastSigned(ctx context.Context, signedTx []byte) (*txtypes.BroadcastTxResponse, error) {
+	err := f.check("BroadcastSigned")
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded sdktypes.Tx
+	if jsonTx := bytes.TrimSpace(signedTx); len(jsonTx) > 0 && jsonTx[0] == '{' {
+		decoded, err = f.txConfig.TxJSONDecoder()(jsonTx)
+	} else {
+		decoded, err = f.txConfig.TxDecoder()(signedTx)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("decode tx: %w", err)
+	}
+	txBytes, err := f.txConfig.TxEncoder()(decoded)
+	if err != nil {
+		return nil, fmt.Errorf("TxEncoder: %s", err)
+	}
+
+	f.deliverMu.Lock()
+	defer f.deliverMu.Unlock()
+
+	return f.deliver(ctx, txBytes, true)
+}
+
+// BuildUnsignedMultisig works as BuildUnsigned for txs sent from the multisig account
+func (f *Fake) BuildUnsignedMultisig(_ context.Context, multisigPubKey cryptotypes.PubKey, msgs ...sdktypes.Msg) (*sdk.OfflineTx, error) {
+	err := f.check("BuildUnsignedMultisig")
+	if err != nil {
+		return nil, err
+	}
+
+	return f.buildUnsigned(sdk.MultisigAddress(multisigPubKey), msgs...)
+}
+
+func (f *Fake) MultisigSignDoc(unsigned *sdk.OfflineTx) ([]byte, error) {
+	return f.keys.MultisigSignDoc(unsigned)
+}
+
+func (f *Fake) SignMultisig(ctx context.Context, signerName string, multisigPubKey cryptotypes.PubKey, unsigned *sdk.OfflineTx) ([]byte, error) {
+	return f.keys.SignMultisig(ctx, signerName, multisigPubKey, unsigned)
+}
+
+func (f *Fake) CombineMultisig(multisigPubKey cryptotypes.PubKey, unsigned *sdk.OfflineTx, signatures ...[]byte) (*sdk.OfflineTx, error) {
+	return f.keys.CombineMultisig(multisigPubKey, unsigned, signatures...)
+}
+
+// buildUnsigned encodes unsigned tx of the messages with the account of the address
+func (f *Fake) buildUnsigned(fromAddress string, msgs ...sdktypes.Msg) (*sdk.OfflineTx, error) {
+	if len(msgs) == 0 {
+		return nil, errors.New("no messages")
+	}
+
+	txn := f.txConfig.NewTxBuilder()
+	err := txn.SetMsgs(msgs...)
+	if err != nil {
+		return nil, fmt.Errorf("SetMsgs: %w", err)
+	}
+	txn.SetGasLimit(GasLimit)
+
+	txJSON, err := f.txConfig.TxJSONEncoder()(txn.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("TxJSONEncoder: %w", err)
+	}
+	txBytes, err := f.txConfig.TxEncoder()(txn.GetTx())
+	if err != nil {
+		return nil, fmt.Errorf("TxEncoder: %w", err)
+	}
+
+	acc := f.Account(fromAddress)
+	return &sdk.OfflineTx{JSON: txJSON, Bytes: txBytes, AccountNumber: acc.Number, Sequence: acc.Sequence}, nil
+}
+
+// broadcastMsgs signs tx of the message sent by the signer and delivers it, as the client broadcasts txs
+func (f *Fake) broadcastMsgs(ctx context.Context, signerName string, msg func(sender string) sdktypes.Msg) (*txtypes.BroadcastTxResponse, error) {
+	info, err := f.keys.GetSigner(signerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// the sequence can't change until the tx is delivered
+	f.deliverMu.Lock()
+	defer f.deliverMu.Unlock()
+
+	unsigned, err := f.buildUnsigned(info.Address, msg(info.Address))
+	if err != nil {
+		return nil, fmt.Errorf("broadcastTx: %s", err)
+	}
+	signed, err := f.keys.SignOffline(ctx, signerName, unsigned.Bytes, unsigned.AccountNumber, unsigned.Sequence)
+	if err != nil {
+		return nil, fmt.Errorf("broadcastTx: Sign: %s", err)
+	}
+
+	resp, err := f.deliver(ctx, signed.Bytes, false)
+	if err != nil {
+		return resp, fmt.Errorf("broadcastTx: %s", err)
+	}
+
+	return resp, nil
+}
+
+// deliver checks and executes the tx in a new block. Txs failed by the messages are committed with non-zero code
+// when commitFailed is set, otherwise they are rejected as the client rejects txs failed in simulation
+func (f *Fake) deliver(ctx context.Context, txBytes []byte, commitFailed bool) (*txtypes.BroadcastTxResponse, error) {
+	decoded, err := f.txConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return nil, fmt.Errorf("TxDecoder: %w", err)
+	}
+	sigTx, ok := decoded.(authsigning.SigVerifiableTx)
+	if !ok {
+		return nil, errors.New("tx is not signed")
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return nil, fmt.Errorf("GetSignaturesV2: %w", err)
+	}
+	if len(sigs) == 0 {
+		return nil, errors.New("tx is not signed")
+	}
+
+	hashBytes := sha256.Sum256(txBytes)
+	hash := strings.ToUpper(hex.EncodeToString(hashBytes[:]))
+	signer := sigTx.GetSigners()[0].String()
+	fee := sigTx.(sdktypes.FeeTx).GetFee()
+
+	// CheckTx
+	f.mu.Lock()
+	acc := f.account(signer)
+	balance := f.balances[signer]
+	f.mu.Unlock()
+	if sigs[0].Sequence != acc.Sequence {
+		return rejected(hash, sdkerrors.Wrapf(sdkerrors.ErrWrongSequence, "%s, expected %d, got %d", sdk.ErrAccountSequenceMismatch, acc.Sequence, sigs[0].Sequence))
+	}
+	if _, negative := balance.SafeSub(fee); negative {
+		return rejected(hash, sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "%s is smaller than %s", balance, fee))
+	}
+
+	// DeliverTx
+	f.mu.Lock()
+	saved := f.snapshot()
+	f.balances[signer] = balance.Sub(fee)
+	f.mu.Unlock()
+
+	var (
+		events []abci.Event
+		logs   sdktypes.ABCIMessageLogs
+	)
+	for i, msg := range decoded.GetMsgs() {
+		msgEvents, execErr := f.execute(ctx, msg)
+		if execErr != nil {
+			err = sdkerrors.Wrapf(execErr, "failed to execute message; message index: %d", i)
+			break
+		}
+		events = append(events, msgEvents...)
+		logs = append(logs, sdktypes.ABCIMessageLog{MsgIndex: uint32(i), Events: sdktypes.StringifyEvents(msgEvents)})
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err != nil {
+		f.restore(saved)
+		if !commitFailed {
+			return nil, fmt.Errorf("Simulate: %s", err)
+		}
+		// fees are paid by failed txs
+		f.balances[signer] = balance.Sub(fee)
+	}
+
+	acc.Sequence++
+	f.accounts[signer] = acc
+	f.height++
+
+	result := abci.ExecTxResult{GasWanted: int64(sigTx.(sdktypes.FeeTx).GetGas()), GasUsed: GasUsed}
+	if err != nil {
+		result.Codespace, result.Code, result.Log = sdkerrors.ABCIInfo(err, false)
+	} else {
+		result.Log = logs.String()
+		result.Events = events
+	}
+	entry := &txEntry{hash: hash, result: &coretypes.ResultTx{Hash: hashBytes[:], Height: f.height, Tx: txBytes, TxResult: result}}
+	f.txs = append(f.txs, entry)
+	f.txsByHash[hash] = entry
+
+	return &txtypes.BroadcastTxResponse{TxResponse: &sdktypes.TxResponse{TxHash: hash}}, nil
+}
+
+// rejected returns response of the tx rejected by CheckTx and the error the client returns for it
+func rejected(hash string, err error) (*txtypes.BroadcastTxResponse, error) {
+	codespace, code, rawLog := sdkerrors.ABCIInfo(err, false)
+	resp := &txtypes.BroadcastTxResponse{TxResponse: &sdktypes.TxResponse{TxHash: hash, Codespace: codespace, Code: code, RawLog: rawLog}}
+
+	return resp, fmt.Errorf("txHash.GetTxResponse().RawLog: %s", rawLog)
+}
+
+// execute runs the message and returns its events
+func (f *Fake) execute(ctx context.Context, msg sdktypes.Msg) ([]abci.Event, error) {
+	sender := msg.GetSigners()[0].String()
+	events := []abci.Event{newEvent("message", "action", sdktypes.MsgTypeURL(msg), "sender", sender)}
+
+	switch msg := msg.(type) {
+	case *wasmtypes.MsgExecuteContract:
+		f.mu.Lock()
+		contract, ok := f.contracts[msg.Contract]
+		f.mu.Unlock()
+		if !ok {
+			return nil, sdkerrors.Wrapf(wasmtypes.ErrNotFound, "contract %s", msg.Contract)
+		}
+
+		err := f.transfer(sender, msg.Contract, msg.Funds)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, newEvent(wasmtypes.EventTypeExecute, wasmtypes.AttributeKeyContractAddr, msg.Contract))
+		if contract.Execute == nil {
+			return events, nil
+		}
+
+		contractEvents, err := contract.Execute(ctx, Call{Contract: msg.Contract, Sender: sender, Msg: msg.Msg, Funds: msg.Funds})
+		if err != nil {
+			return nil, sdkerrors.Wrap(wasmtypes.ErrExecuteFailed, err.Error())
+		}
+
+		return append(events, contractEventsOf(msg.Contract, contractEvents)...), nil
+
+	case *wasmtypes.MsgInstantiateContract:
+		f.mu.Lock()
+		code, ok := f.codes[msg.CodeID]
+		instanceID := f.nextInstanceID
+		f.nextInstanceID++
+		f.mu.Unlock()
+		if !ok {
+			return nil, sdkerrors.Wrapf(wasmtypes.ErrNotFound, "code %d", msg.CodeID)
+		}
+
+		contractAddress := buildContractAddress(msg.CodeID, instanceID)
+		f.SetContract(contractAddress, Contract{
+			CodeID:  msg.CodeID,
+			Label:   msg.Label,
+			Creator: sender,
+			Admin:   msg.Admin,
+			InitMsg: msg.Msg,
+			Query:   code.Query,
+			Execute: code.Execute,
+		})
+		err := f.transfer(sender, contractAddress, msg.Funds)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, newEvent(wasmtypes.EventTypeInstantiate,
+			wasmtypes.AttributeKeyContractAddr, contractAddress, wasmtypes.AttributeKeyCodeID, strconv.FormatUint(msg.CodeID, 10)))
+		if code.Instantiate == nil {
+			return events, nil
+		}
+
+		contractEvents, err := code.Instantiate(ctx, Call{Contract: contractAddress, Sender: sender, Msg: msg.Msg, Funds: msg.Funds})
+		if err != nil {
+			return nil, sdkerrors.Wrap(wasmtypes.ErrInstantiateFailed, err.Error())
+		}
+
+		return append(events, contractEventsOf(contractAddress, contractEvents)...), nil
+
+	case *banktypes.MsgSend:
+		err := f.transfer(msg.FromAddress, msg.ToAddress, msg.Amount)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(events, newEvent(banktypes.EventTypeTransfer,
+			banktypes.AttributeKeyRecipient, msg.ToAddress, banktypes.AttributeKeySender, msg.FromAddress, sdktypes.AttributeKeyAmount, msg.Amount.String())), nil
+	}
+
+	return events, nil
+}
+
+// transfer moves coins between the balances
+func (f *Fake) transfer(from, to string, coins sdktypes.Coins) error {
+	if coins.IsZero() {
+		return nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	balance, negative := f.balances[from].SafeSub(coins)
+	if negative {
+		return sdkerrors.Wrapf(sdkerrors.ErrInsufficientFunds, "%s is smaller than %s", f.balances[from], coins)
+	}
+	f.balances[from] = balance
+	f.balances[to] = f.balances[to].Add(coins...)
+
+	return nil
+}
+
+func (f *Fake) snapshot() snapshot {
+	s := snapshot{
+		balances:       make(map[string]sdktypes.Coins, len(f.balances)),
+		contracts:      make(map[string]*Contract, len(f.contracts)),
+		nextInstanceID: f.nextInstanceID,
+	}
+	for k, v := range f.balances {
+		s.balances[k] = v
+	}
+	for k, v := range f.contracts {
+		s.contracts[k] = v
+	}
+
+	return s
+}
+
+func (f *Fake) restore(s snapshot) {
+	f.balances, f.contracts, f.nextInstanceID = s.balances, s.contracts, s.nextInstanceID
+}
+
+// buildContractAddress builds contract address as wasmd does
+func buildContractAddress(codeID, instanceID uint64) string {
+	contractID := make([]byte, 16)
+	binary.BigEndian.PutUint64(contractID[:8], codeID)
+	binary.BigEndian.PutUint64(contractID[8:], instanceID)
+
+	return sdktypes.AccAddress(address.Module(wasmtypes.ModuleName, contractID)[:wasmtypes.ContractAddrLen]).String()
+}
+
+// contractEventsOf adds _contract_address attribute to wasm events emitted by the contract
+func contractEventsOf(contract string, events []abci.Event) []abci.Event {
+	res := make([]abci.Event, 0, len(events))
+	for _, e := range events {
+		if e.Type == wasmtypes.WasmModuleEventType || strings.HasPrefix(e.Type, wasmtypes.CustomContractEventPrefix) {
+			attrs := []abci.EventAttribute{{Key: []byte(wasmtypes.AttributeKeyContractAddr), Value: []byte(contract), Index: true}}
+			e.Attributes = append(attrs, e.Attributes...)
+		}
+		res = append(res, e)
+	}
+
+	return res
+}
+
+func newEvent(eventType string, keyValues ...string) abci.Event {
+	e := abci.Event{Type: eventType}
+	for i := 0; i+1 < len(keyValues); i += 2 {
+		e.Attributes = append(e.Attributes, abci.EventAttribute{Key: []byte(keyValues[i]), Value: []byte(keyValues[i+1]), Index: true})
+	}
+
+	return e
+}
+
+// GetTxByHash returns the delivered tx, retries are not needed as txs are delivered on broadcast
+func (f *Fake) GetTxByHash(_ context.Context, txHash string, _ uint, _ time.Duration) (*coretypes.ResultTx, error) {
+	err := f.check("GetTxByHash")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := f.tx(txHash)
+	if err != nil {
+		return nil, err
+	}
+	if tx.result.TxResult.Code != 0 {
+		return tx.result, fmt.Errorf("non-zero code: %d", tx.result.TxResult.Code)
+	}
+
+	return tx.result, nil
+}
+
+// GetTxMetaResponseByHash returns the delivered tx, retries are not needed as txs are delivered on broadcast
+func (f *Fake) GetTxMetaResponseByHash(_ context.Context, txHash string, _ uint, _ time.Duration) (*txtypes.GetTxResponse, error) {
+	err := f.check("GetTxMetaResponseByHash")
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := f.tx(txHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var protoTx txtypes.Tx
+	err = protoTx.Unmarshal(tx.result.Tx)
+	if err != nil {
+		return nil, fmt.Errorf("Unmarshal: %w", err)
+	}
+	anyTx, err := codectypes.NewAnyWithValue(&protoTx)
+	if err != nil {
+		return nil, fmt.Errorf("NewAnyWithValue: %w", err)
+	}
+	resp := &txtypes.GetTxResponse{
+		Tx:         &protoTx,
+		TxResponse: sdktypes.NewResponseResultTx(tx.result, anyTx, blockTime(tx.result.Height).Format(time.RFC3339)),
+	}
+	if resp.TxResponse.Code != 0 {
+		return resp, fmt.Errorf("non-zero code: %d", resp.TxResponse.Code)
+	}
+
+	return resp, nil
+}
+
+func (f *Fake) tx(txHash string) (*txEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	tx, ok := f.txsByHash[strings.ToUpper(txHash)]
+	if !ok {
+		return nil, fmt.Errorf("GetTx: tx (%s) not found", txHash)
+	}
+
+	return tx, nil
+}
+
+func (f *Fake) DecodeTx(tx *coretypes.ResultTx) (*sdk.TxRecord, error) {
+	return f.keys.DecodeTx(tx)
+}
+
+// HandleTxsByHeight passes events of the contract txs to the callback with tx height and hash events appended, as the client does
+func (f *Fake) HandleTxsByHeight(ctx context.Context, contractAddress string, heightFrom, heightTo int64, acknowledge func(ctx context.Context, msg []abci.Event) error) error {
+	return f.HandleTxsByRange(ctx, sdk.TxSearchConfig{
+		ContractAddress: contractAddress,
+		Heights:         sdk.HeightRange{From: heightFrom, To: heightTo},
+	}, func(ctx context.Context, tx *coretypes.ResultTx) error {
+		events := make([]abci.Event, 0, len(tx.TxResult.Events)+2)
+		events = append(events, tx.TxResult.Events...)
+		events = append(events,
+			newEvent("tx", "height", strconv.FormatInt(tx.Height, 10)),
+			newEvent("tx", "hash", hex.EncodeToString(tx.Hash)),
+		)
+
+		return acknowledge(ctx, events)
+	})
+}
+
+func (f *Fake) HandleDecodedTxsByHeight(ctx context.Context, contractAddress string, heightFrom, heightTo int64, acknowledge func(ctx context.Context, tx *sdk.TxRecord) error) error {
+	return f.HandleTxsByRange(ctx, sdk.TxSearchConfig{
+		ContractAddress: contractAddress,
+		Heights:         sdk.HeightRange{From: heightFrom, To: heightTo},
+	}, func(ctx context.Context, tx *coretypes.ResultTx) error {
+		record, err := f.DecodeTx(tx)
+		if err != nil {
+			return fmt.Errorf("DecodeTx %X: %w", tx.Hash, err)
+		}
+		record.BlockTime = blockTime(tx.Height)
+
+		return acknowledge(ctx, record)
+	})
+}
+
+func (f *Fake) HandleTxsByRange(ctx context.Context, cfg sdk.TxSearchConfig, handle func(ctx context.Context, tx *coretypes.ResultTx) error) error {
+	err := cfg.Validate()
+	if err != nil {
+		return err
+	}
+	err = f.check("HandleTxsByRange")
+	if err != nil {
+		return err
+	}
+
+	from, to := cfg.Heights.Bounds()
+	for _, tx := range f.search(cfg.ContractAddress, from, to) {
+		err = handle(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ScanTxs delivers the txs in height order, reporting progress once per shard. Query conditions are not supported
+func (f *Fake) ScanTxs(ctx context.Context, cfg sdk.ScanConfig, handle func(ctx context.Context, tx *coretypes.ResultTx) error) error {
+	err := cfg.Validate()
+	if err != nil {
+		return err
+	}
+	if cfg.Query != "" {
+		return fmt.Errorf("Query: %w", ErrNotSupported)
+	}
+	err = f.check("ScanTxs")
+	if err != nil {
+		return err
+	}
+
+	progress := sdk.ScanProgress{
+		ShardsTotal: int((cfg.HeightTo - cfg.HeightFrom + cfg.ShardSize) / cfg.ShardSize),
+		Workers:     cfg.Workers,
+		PageSize:    cfg.PageSize,
+	}
+	for from := cfg.HeightFrom; from <= cfg.HeightTo; from += cfg.ShardSize {
+		to := min(from+cfg.ShardSize-1, cfg.HeightTo)
+		for _, tx := range f.search(cfg.ContractAddress, from, to) {
+			err = handle(ctx, tx)
+			if err != nil {
+				return err
+			}
+			progress.Txs++
+		}
+
+		progress.ShardsDone++
+		progress.Height = to
+		if cfg.OnProgress != nil {
+			cfg.OnProgress(progress)
+		}
+	}
+
+	return nil
+}
+
+// search returns txs in [from, to] emitting wasm events of the contract, as wasm._contract_address CONTAINS query matches them
+func (f *Fake) search(contract string, from, to int64) []*coretypes.ResultTx {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var res []*coretypes.ResultTx
+	for _, tx := range f.txs {
+		if tx.result.Height < from || tx.result.Height > to {
+			continue
+		}
+		if contract == "" || emittedBy(tx.result.TxResult.Events, contract) {
+			res = append(res, tx.result)
+		}
+	}
+
+	return res
+}
+
+func emittedBy(events []abci.Event, contract string) bool {
+	for _, e := range events {
+		if e.Type != wasmtypes.WasmModuleEventType {
+			continue
+		}
+		for _, attr := range e.Attributes {
+			if string(attr.Key) == wasmtypes.AttributeKeyContractAddr && strings.Contains(string(attr.Value), contract) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (f *Fake) GetLatestHeight(_ context.Context) (int64, error) {
+	err := f.check("GetLatestHeight")
+	if err != nil {
+		return 0, err
+	}
+
+	return f.Height(), nil
+}
+
+// GetBlock returns block with the txs delivered at the height, blocks without txs are empty
+func (f *Fake) GetBlock(_ context.Context, height int64) (*sdk.BlockData, error) {
+	err := f.check("GetBlock")
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if height <= 0 || height > f.height {
+		return nil, fmt.Errorf("height %d is not available, latest height is %d", height, f.height)
+	}
+
+	hash := sha256.Sum256(binary.BigEndian.AppendUint64(nil, uint64(height)))
+	block := &sdk.BlockData{
+		Height: height,
+		Hash:   strings.ToUpper(hex.EncodeToString(hash[:])),
+		Time:   blockTime(height),
+	}
+	for _, tx := range f.txs {
+		if tx.result.Height == height {
+			result := tx.result.TxResult
+			block.Txs = append(block.Txs, sdk.BlockTx{Hash: tx.hash, Index: tx.result.Index, Tx: tx.result.Tx, Result: &result})
+		}
+	}
+
+	return block, nil
+}
+
+// FollowBlocks delivers blocks from StartHeight as the client does, new blocks are committed by txs and AddBlocks
+func (f *Fake) FollowBlocks(ctx context.Context, cfg sdk.FollowConfig, handle func(ctx context.Context, block *sdk.BlockData) error) error {
+	err := cfg.Validate()
+	if err != nil {
+		return err
+	}
+
+	height := cfg.StartHeight
+	if height == 0 {
+		height, err = f.GetLatestHeight(ctx)
+		if err != nil {
+			return fmt.Errorf("GetLatestHeight: %w", err)
+		}
+	}
+
+	for cfg.EndHeight == 0 || height <= cfg.EndHeight {
+		for {
+			latest, err := f.GetLatestHeight(ctx)
+			if err != nil {
+				return fmt.Errorf("GetLatestHeight: %w", err)
+			}
+			if height <= latest {
+				break
+			}
+
+			select {
+			case <-time.After(cfg.PollInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		block, err := f.GetBlock(ctx, height)
+		if err != nil {
+			return fmt.Errorf("GetBlock %d: %w", height, err)
+		}
+
+		err = handle(ctx, block)
+		if err != nil {
+			return err
+		}
+
+		height++
+	}
+
+	return nil
+}
@@ -0,0 +1,407 @@
+// Package sdktest provides Fake, an in-memory fake chain implementing sdk.ClientAPI, so code depending on the client
+// can be tested deterministically without a node
+package sdktest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/spell-club/sei-sdk"
+)
+
+const (
+	// ChainID is the chain ID txs of the fake chain are signed for
+	ChainID sdk.ChainID = "sdktest-1"
+	// GasLimit is the gas limit of txs built by the fake chain
+	GasLimit = 200_000
+	// GasUsed is the gas used by every delivered tx
+	GasUsed = 100_000
+	// BlockInterval is the time between blocks, block time of height h is GenesisTime + h*BlockInterval
+	BlockInterval = time.Second
+)
+
+// GenesisTime is the time of the fake chain at height 0
+var GenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// ErrNotSupported is returned by the methods, which can't be faked
+var ErrNotSupported = errors.New("not supported by sdktest")
+
+type (
+	// Account is the account number and the sequence of an address
+	Account struct {
+		Number   uint64
+		Sequence uint64
+	}
+
+	// Call is an instantiate or execute message sent to a contract
+	Call struct {
+		Contract string
+		Sender   string
+		Msg      []byte
+		Funds    sdktypes.Coins
+	}
+
+	// QueryHandler answers smart queries of a contract
+	QueryHandler func(ctx context.Context, contract string, msg []byte) ([]byte, error)
+
+	// ExecuteHandler executes instantiate or execute messages of a contract and returns the emitted events.
+	// Events of type wasm and wasm-* get the _contract_address attribute as wasmd adds it.
+	// An error fails the tx and reverts balances and contracts changed by it
+	ExecuteHandler func(ctx context.Context, call Call) ([]abci.Event, error)
+
+	// Code is a stored wasm code, contracts instantiated from it get its handlers
+	Code struct {
+		Wasm   []byte
+		Pinned bool
+
+		Instantiate ExecuteHandler
+		Query       QueryHandler
+		Execute     ExecuteHandler
+	}
+
+	// Contract is an instantiated contract
+	Contract struct {
+		CodeID  uint64
+		Label   string
+		Creator string
+		Admin   string
+		// InitMsg is the instantiate message reported by FetchContractHistory
+		InitMsg []byte
+		// State is the raw state returned by RawContractState and FetchAllContractsState
+		State map[string][]byte
+
+		Query   QueryHandler
+		Execute ExecuteHandler
+	}
+)
+
+// Fake is an in-memory fake chain implementing sdk.ClientAPI. Signers are managed by a Client created without a node,
+// so addresses, signatures and tx bytes are real. Every tx is delivered in its own block and balances, accounts
+// and contracts are changed as the chain would, contract calls are answered by scripted handlers.
+// Pagination of queries is ignored. Fake is safe for concurrent use
+type Fake struct {
+	keys     *sdk.Client
+	txConfig client.TxConfig
+
+	// deliverMu serializes txs, handlers are called without holding mu
+	deliverMu sync.Mutex
+
+	mu                sync.Mutex
+	closed            bool
+	errs              map[string]error
+	height            int64
+	accounts          map[string]Account
+	nextAccountNumber uint64
+	balances          map[string]sdktypes.Coins
+	codes             map[uint64]*Code
+	contracts         map[string]*Contract
+	nextInstanceID    uint64
+	txs               []*txEntry
+	txsByHash         map[string]*txEntry
+}
+
+// NewFake creates fake chain at height 0 without accounts, balances and contracts
+func NewFake() (*Fake, error) {
+	keys, err := sdk.NewClient(sdk.Config{
+		ChainID:          ChainID,
+		GRPCHost:         "sdktest:9090",
+		RPCHost:          "http://sdktest:26657",
+		InsecureGRPC:     true,
+		SkipChainIDCheck: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("NewClient: %w", err)
+	}
+
+	registry := codectypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(registry)
+	authtypes.RegisterInterfaces(registry)
+	banktypes.RegisterInterfaces(registry)
+	wasmtypes.RegisterInterfaces(registry)
+
+	return &Fake{
+		keys:              keys,
+		txConfig:          authtx.NewTxConfig(codec.NewProtoCodec(registry), authtx.DefaultSignModes),
+		errs:              make(map[string]error),
+		accounts:          make(map[string]Account),
+		nextAccountNumber: 1,
+		balances:          make(map[string]sdktypes.Coins),
+		codes:             make(map[uint64]*Code),
+		contracts:         make(map[string]*Contract),
+		nextInstanceID:    1,
+		txsByHash:         make(map[string]*txEntry),
+	}, nil
+}
+
+var _ sdk.ClientAPI = (*Fake)(nil)
+
+// SetError makes every call of the ClientAPI method with the name, e.g. "Execute", fail with the error. Nil err clears it
+func (f *Fake) SetError(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.errs, method)
+		return
+	}
+	f.errs[method] = err
+}
+
+// SetBalance replaces balance of the address
+func (f *Fake) SetBalance(address string, coins ...sdktypes.Coin) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.balances[address] = sdktypes.NewCoins(coins...)
+}
+
+// Balance returns balance of the address in the denom
+func (f *Fake) Balance(address, denom string) sdktypes.Coin {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return sdktypes.NewCoin(denom, f.balances[address].AmountOf(denom))
+}
+
+// SetAccount sets account number and sequence of the address. Accounts of unknown addresses are created
+// with the next account number and zero sequence on first use
+func (f *Fake) SetAccount(address string, number, sequence uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.accounts[address] = Account{Number: number, Sequence: sequence}
+	f.nextAccountNumber = max(f.nextAccountNumber, number+1)
+}
+
+// Account returns account of the address, creating it when it is unknown
+func (f *Fake) Account(address string) Account {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.account(address)
+}
+
+func (f *Fake) account(address string) Account {
+	acc, ok := f.accounts[address]
+	if !ok {
+		acc = Account{Number: f.nextAccountNumber}
+		f.accounts[address] = acc
+		f.nextAccountNumber++
+	}
+
+	return acc
+}
+
+// SetCode stores the code, so contracts can be instantiated from it
+func (f *Fake) SetCode(codeID uint64, code Code) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.codes[codeID] = &code
+}
+
+// SetContract sets the contract at the address, e.g. a contract deployed before the test
+func (f *Fake) SetContract(address string, contract Contract) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.contracts[address] = &contract
+}
+
+// SetContractState sets raw state of the contract under the key, nil value deletes it
+func (f *Fake) SetContractState(address string, key, value []byte) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	contract, ok := f.contracts[address]
+	if !ok {
+		return fmt.Errorf("contract %s not found", address)
+	}
+
+	// contracts are replaced instead of modified, so failed txs can restore them
+	updated := *contract
+	updated.State = make(map[string][]byte, len(contract.State)+1)
+	for k, v := range contract.State {
+		updated.State[k] = v
+	}
+	if value == nil {
+		delete(updated.State, string(key))
+	} else {
+		updated.State[string(key)] = value
+	}
+	f.contracts[address] = &updated
+
+	return nil
+}
+
+// Height returns height of the latest block
+func (f *Fake) Height() int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.height
+}
+
+// AddBlocks commits n empty blocks
+func (f *Fake) AddBlocks(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.height += int64(n)
+}
+
+// Broadcasts returns delivered txs in order, including failed ones
+func (f *Fake) Broadcasts() []*sdk.TxRecord {
+	f.mu.Lock()
+	txs := make([]*txEntry, len(f.txs))
+	copy(txs, f.txs)
+	f.mu.Unlock()
+
+	records := make([]*sdk.TxRecord, 0, len(txs))
+	for _, tx := range txs {
+		record, err := f.DecodeTx(tx.result)
+		if err != nil {
+			// txs are decoded on delivery
+			panic(err)
+		}
+		record.BlockTime = blockTime(tx.result.Height)
+		records = append(records, record)
+	}
+
+	return records
+}
+
+// check returns error of the method set by SetError, or ErrClientClosed when the fake is closed
+func (f *Fake) check(method string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return sdk.ErrClientClosed
+	}
+
+	return f.errs[method]
+}
+
+func blockTime(height int64) time.Time {
+	return GenesisTime.Add(time.Duration(height) * BlockInterval)
+}
+
+// Close closes the fake, methods accessing the chain fail with sdk.ErrClientClosed afterwards
+func (f *Fake) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil
+	}
+	f.closed = true
+
+	return f.keys.Close()
+}
+
+// Health reports a single healthy endpoint
+func (f *Fake) Health(_ context.Context) (*sdk.Health, error) {
+	err := f.check("Health")
+	if err != nil {
+		return nil, err
+	}
+
+	height := f.Height()
+	return &sdk.Health{Endpoints: []sdk.EndpointHealth{{
+		GRPCHost:  "sdktest",
+		RPCHost:   "sdktest",
+		Healthy:   true,
+		ChainID:   string(ChainID),
+		Height:    height,
+		BlockTime: blockTime(height),
+	}}}, nil
+}
+
+func (f *Fake) Ping(_ context.Context) error {
+	return f.check("Ping")
+}
+
+func (f *Fake) GetSignerAddresses() []string {
+	return f.keys.GetSignerAddresses()
+}
+
+func (f *Fake) AddSigner(name, mnemonic string) (string, error) {
+	return f.keys.AddSigner(name, mnemonic)
+}
+
+func (f *Fake) AddSignerWithOptions(name, mnemonic string, opts sdk.KeyOptions) (string, error) {
+	return f.keys.AddSignerWithOptions(name, mnemonic, opts)
+}
+
+func (f *Fake) AddSignersFromMnemonic(namePrefix, mnemonic string, count uint32, opts sdk.KeyOptions) ([]sdk.SignerInfo, error) {
+	return f.keys.AddSignersFromMnemonic(namePrefix, mnemonic, count, opts)
+}
+
+func (f *Fake) AddSignerFromPrivKey(name, privKeyHex string) (string, error) {
+	return f.keys.AddSignerFromPrivKey(name, privKeyHex)
+}
+
+func (f *Fake) AddSignerFromArmor(name, armor, passphrase string) (string, error) {
+	return f.keys.AddSignerFromArmor(name, armor, passphrase)
+}
+
+func (f *Fake) AddSignerFromKeystore(name string, keystoreJSON []byte, passphrase string) (string, error) {
+	return f.keys.AddSignerFromKeystore(name, keystoreJSON, passphrase)
+}
+
+func (f *Fake) AddExternalSigner(name string, key sdk.Signer) (string, error) {
+	return f.keys.AddExternalSigner(name, key)
+}
+
+func (f *Fake) ReplaceSigner(name, mnemonic string) (string, error) {
+	return f.keys.ReplaceSigner(name, mnemonic)
+}
+
+func (f *Fake) ReplaceSignerWithOptions(name, mnemonic string, opts sdk.KeyOptions) (string, error) {
+	return f.keys.ReplaceSignerWithOptions(name, mnemonic, opts)
+}
+
+func (f *Fake) ReplaceExternalSigner(name string, key sdk.Signer) (string, error) {
+	return f.keys.ReplaceExternalSigner(name, key)
+}
+
+func (f *Fake) RemoveSigner(name string) error {
+	return f.keys.RemoveSigner(name)
+}
+
+func (f *Fake) ListSigners() []sdk.SignerInfo {
+	return f.keys.ListSigners()
+}
+
+func (f *Fake) GetSigner(name string) (sdk.SignerInfo, error) {
+	return f.keys.GetSigner(name)
+}
+
+func (f *Fake) GetSignerPubKey(name string) (cryptotypes.PubKey, error) {
+	return f.keys.GetSignerPubKey(name)
+}
+
+func (f *Fake) GetSignerByEVMAddress(evmAddress string) (sdk.SignerInfo, error) {
+	return f.keys.GetSignerByEVMAddress(evmAddress)
+}
+
+func (f *Fake) SignArbitrary(ctx context.Context, signerName string, data []byte) (legacytx.StdSignature, error) {
+	return f.keys.SignArbitrary(ctx, signerName, data)
+}
@@ -0,0 +1,289 @@
+package sdktest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	"gotest.tools/assert"
+
+	sdk "github.com/spell-club/sei-sdk"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// newTestFake returns fake with funded signer "default" and code 1 of a counter contract
+func newTestFake(t *testing.T) (*Fake, string) {
+	f, err := NewFake()
+	assert.NilError(t, err)
+	t.Cleanup(func() { _ = f.Close() })
+
+	address, err := f.AddSigner("default", testMnemonic)
+	assert.NilError(t, err)
+	f.SetBalance(address, sdktypes.NewInt64Coin("usei", 1_000_000))
+
+	f.SetCode(1, Code{
+		Wasm: []byte("counter"),
+		Query: func(_ context.Context, contract string, _ []byte) ([]byte, error) {
+			state, err := f.RawContractState(context.Background(), contract, []byte("count"))
+			if err != nil {
+				return nil, err
+			}
+			return json.Marshal(map[string]string{"count": string(state.Data)})
+		},
+		Execute: func(_ context.Context, call Call) ([]abci.Event, error) {
+			var msg struct {
+				Increment *struct{} `json:"increment"`
+			}
+			err := json.Unmarshal(call.Msg, &msg)
+			if err != nil || msg.Increment == nil {
+				return nil, errors.New("unknown message")
+			}
+			err = f.SetContractState(call.Contract, []byte("count"), []byte("1"))
+			if err != nil {
+				return nil, err
+			}
+			return []abci.Event{newEvent(wasmtypes.WasmModuleEventType, "action", "increment")}, nil
+		},
+	})
+
+	return f, address
+}
+
+func TestFake_InstantiateExecute(t *testing.T) {
+	ctx := context.Background()
+	f, address := newTestFake(t)
+
+	resp, err := f.InstantiateJSON(ctx, "default", 1, "counter", map[string]any{}, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, f.Height(), int64(1))
+
+	contracts, err := f.FetchContractsByCode(ctx, 1, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, len(contracts.Contracts), 1)
+	contract := contracts.Contracts[0]
+	assert.Equal(t, contract, buildContractAddress(1, 1))
+
+	info, err := f.FetchContractInfo(ctx, contract)
+	assert.NilError(t, err)
+	assert.Equal(t, info.Creator, address)
+	assert.Equal(t, info.Label, "counter")
+
+	resp, err = f.ExecuteJSON(ctx, "default", contract, map[string]any{"increment": struct{}{}})
+	assert.NilError(t, err)
+	assert.Equal(t, f.Account(address).Sequence, uint64(2))
+
+	tx, err := f.GetTxByHash(ctx, resp.TxResponse.TxHash, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, tx.Height, int64(2))
+	assert.Assert(t, emittedBy(tx.TxResult.Events, contract))
+
+	meta, err := f.GetTxMetaResponseByHash(ctx, resp.TxResponse.TxHash, 0, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, meta.TxResponse.Height, int64(2))
+
+	query, err := f.SmartContractState(ctx, contract, []byte(`{"count":{}}`))
+	assert.NilError(t, err)
+	assert.Equal(t, string(query.Data), `{"count":"1"}`)
+
+	var handled [][]abci.Event
+	err = f.HandleTxsByHeight(ctx, contract, 1, 10, func(_ context.Context, events []abci.Event) error {
+		handled = append(handled, events)
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, len(handled), 1)
+	assert.Equal(t, handled[0][len(handled[0])-2].Type, "tx")
+	assert.Equal(t, len(tx.TxResult.Events), len(handled[0])-2)
+
+	broadcasts := f.Broadcasts()
+	assert.Equal(t, len(broadcasts), 2)
+	assert.Equal(t, broadcasts[1].Hash, resp.TxResponse.TxHash)
+	assert.DeepEqual(t, broadcasts[1].Signers, []string{address})
+	assert.Equal(t, broadcasts[1].BlockTime, GenesisTime.Add(2*BlockInterval))
+
+	block, err := f.GetBlock(ctx, 2)
+	assert.NilError(t, err)
+	assert.Equal(t, len(block.Txs), 1)
+	assert.Equal(t, block.Txs[0].Hash, resp.TxResponse.TxHash)
+}
+
+func TestFake_FailedTx(t *testing.T) {
+	ctx := context.Background()
+	f, address := newTestFake(t)
+
+	_, err := f.Instantiate(ctx, "default", 1, "counter", "{}", sdktypes.NewCoins(sdktypes.NewInt64Coin("usei", 100)))
+	assert.NilError(t, err)
+	contract := buildContractAddress(1, 1)
+	assert.Equal(t, f.Balance(contract, "usei").Amount.Int64(), int64(100))
+
+	_, err = f.Execute(ctx, "default", contract, `{"reset":{}}`)
+	assert.ErrorContains(t, err, "unknown message")
+	assert.Equal(t, f.Account(address).Sequence, uint64(1))
+	assert.Equal(t, f.Height(), int64(1))
+	assert.Equal(t, len(f.Broadcasts()), 1)
+
+	_, err = f.Execute(ctx, "default", "sei1unknown", `{"increment":{}}`)
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestFake_BroadcastSigned(t *testing.T) {
+	ctx := context.Background()
+	f, address := newTestFake(t)
+	recipient := buildContractAddress(9, 9)
+
+	unsigned, err := f.BuildUnsigned(ctx, address, &banktypes.MsgSend{FromAddress: address, ToAddress: recipient, Amount: sdktypes.NewCoins(sdktypes.NewInt64Coin("usei", 10))})
+	assert.NilError(t, err)
+
+	stale, err := f.SignOffline(ctx, "default", unsigned.Bytes, unsigned.AccountNumber, unsigned.Sequence+1)
+	assert.NilError(t, err)
+	_, err = f.BroadcastSigned(ctx, stale.Bytes)
+	assert.ErrorContains(t, err, sdk.ErrAccountSequenceMismatch)
+
+	signed, err := f.SignOffline(ctx, "default", unsigned.Bytes, unsigned.AccountNumber, unsigned.Sequence)
+	assert.NilError(t, err)
+	resp, err := f.BroadcastSigned(ctx, signed.JSON)
+	assert.NilError(t, err)
+	assert.Equal(t, resp.TxResponse.Code, uint32(0))
+	assert.Equal(t, f.Balance(recipient, "usei").Amount.Int64(), int64(10))
+	assert.Equal(t, f.Balance(address, "usei").Amount.Int64(), int64(999_990))
+}
+
+func TestFake_BroadcastSignedContext(t *testing.T) {
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+	f, address := newTestFake(t)
+
+	var seen any
+	contract := buildContractAddress(2, 1)
+	f.SetContract(contract, Contract{CodeID: 2, Execute: func(ctx context.Context, _ Call) ([]abci.Event, error) {
+		seen = ctx.Value(ctxKey{})
+		return nil, nil
+	}})
+
+	unsigned, err := f.BuildUnsigned(ctx, address, &wasmtypes.MsgExecuteContract{Sender: address, Contract: contract, Msg: []byte(`{}`)})
+	assert.NilError(t, err)
+	signed, err := f.SignOffline(ctx, "default", unsigned.Bytes, unsigned.AccountNumber, unsigned.Sequence)
+	assert.NilError(t, err)
+	_, err = f.BroadcastSigned(ctx, signed.Bytes)
+	assert.NilError(t, err)
+	assert.Equal(t, seen, "value")
+}
+
+// newCountedFake returns fake with a counter instantiated at height 1 and incremented at heights 2, 3 and 4
+func newCountedFake(t *testing.T) (*Fake, string) {
+	ctx := context.Background()
+	f, _ := newTestFake(t)
+
+	_, err := f.Instantiate(ctx, "default", 1, "counter", "{}", nil)
+	assert.NilError(t, err)
+	contract := buildContractAddress(1, 1)
+	for range 3 {
+		_, err = f.Execute(ctx, "default", contract, `{"increment":{}}`)
+		assert.NilError(t, err)
+	}
+
+	return f, contract
+}
+
+func TestFake_HandleTxsByRange(t *testing.T) {
+	f, contract := newCountedFake(t)
+
+	var heights []int64
+	err := f.HandleTxsByRange(context.Background(), sdk.TxSearchConfig{ContractAddress: contract, Heights: sdk.HeightRange{From: 1, To: 3}},
+		func(_ context.Context, tx *coretypes.ResultTx) error {
+			heights = append(heights, tx.Height)
+			return nil
+		})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, heights, []int64{2, 3})
+
+	err = f.HandleTxsByRange(context.Background(), sdk.TxSearchConfig{ContractAddress: contract, Heights: sdk.HeightRange{From: 3, To: 2}},
+		func(context.Context, *coretypes.ResultTx) error { return nil })
+	assert.Assert(t, err != nil)
+}
+
+func TestFake_ScanTxs(t *testing.T) {
+	f, contract := newCountedFake(t)
+
+	var (
+		heights  []int64
+		progress []sdk.ScanProgress
+	)
+	cfg := sdk.ScanConfig{
+		ContractAddress: contract,
+		HeightFrom:      1,
+		HeightTo:        4,
+		ShardSize:       2,
+		OnProgress:      func(p sdk.ScanProgress) { progress = append(progress, p) },
+	}
+	err := f.ScanTxs(context.Background(), cfg, func(_ context.Context, tx *coretypes.ResultTx) error {
+		heights = append(heights, tx.Height)
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, heights, []int64{2, 3, 4})
+	assert.Equal(t, len(progress), 2)
+	assert.Equal(t, progress[1].ShardsDone, 2)
+	assert.Equal(t, progress[1].ShardsTotal, 2)
+	assert.Equal(t, progress[1].Height, int64(4))
+	assert.Equal(t, progress[1].Txs, 3)
+
+	cfg.Query = "message.action='/cosmwasm.wasm.v1.MsgExecuteContract'"
+	err = f.ScanTxs(context.Background(), cfg, func(context.Context, *coretypes.ResultTx) error { return nil })
+	assert.Assert(t, errors.Is(err, ErrNotSupported))
+}
+
+func TestFake_FollowBlocks(t *testing.T) {
+	ctx := context.Background()
+	f, contract := newCountedFake(t)
+
+	// backfill
+	var heights []int64
+	err := f.FollowBlocks(ctx, sdk.FollowConfig{StartHeight: 2, EndHeight: 4}, func(_ context.Context, block *sdk.BlockData) error {
+		heights = append(heights, block.Height)
+		assert.Equal(t, len(block.Txs), 1)
+		return nil
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, heights, []int64{2, 3, 4})
+
+	// new blocks are delivered once they are produced
+	stop := errors.New("stop")
+	done := make(chan error, 1)
+	go func() {
+		done <- f.FollowBlocks(ctx, sdk.FollowConfig{StartHeight: 5, PollInterval: time.Millisecond}, func(_ context.Context, block *sdk.BlockData) error {
+			assert.Equal(t, block.Height, int64(5))
+			return stop
+		})
+	}()
+	_, err = f.Execute(ctx, "default", contract, `{"increment":{}}`)
+	assert.NilError(t, err)
+	assert.Assert(t, errors.Is(<-done, stop))
+}
+
+func TestFake_SetErrorClose(t *testing.T) {
+	ctx := context.Background()
+	f, address := newTestFake(t)
+
+	unavailable := errors.New("unavailable")
+	f.SetError("GetBankBalance", unavailable)
+	_, err := f.GetBankBalance(ctx, address, "usei")
+	assert.Assert(t, errors.Is(err, unavailable))
+
+	f.SetError("GetBankBalance", nil)
+	balance, err := f.GetBankBalance(ctx, address, "usei")
+	assert.NilError(t, err)
+	assert.Equal(t, balance.Balance.Amount.Int64(), int64(1_000_000))
+
+	assert.NilError(t, f.Close())
+	_, err = f.GetLatestHeight(ctx)
+	assert.Assert(t, errors.Is(err, sdk.ErrClientClosed))
+}
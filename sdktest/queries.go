@@ -0,0 +1,206 @@
+package sdktest
+
+import (
+	"context"
+	"crypto/sha256"
+	"sort"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+)
+
+func (f *Fake) GetBankBalance(_ context.Context, address, denom string) (*banktypes.QueryBalanceResponse, error) {
+	err := f.check("GetBankBalance")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = sdktypes.AccAddressFromBech32(address)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, err.Error())
+	}
+	balance := f.Balance(address, denom)
+
+	return &banktypes.QueryBalanceResponse{Balance: &balance}, nil
+}
+
+func (f *Fake) FetchContractInfo(_ context.Context, address string) (*wasmtypes.QueryContractInfoResponse, error) {
+	contract, err := f.contract("FetchContractInfo", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wasmtypes.QueryContractInfoResponse{
+		Address: address,
+		ContractInfo: wasmtypes.ContractInfo{
+			CodeID:  contract.CodeID,
+			Creator: contract.Creator,
+			Admin:   contract.Admin,
+			Label:   contract.Label,
+		},
+	}, nil
+}
+
+// FetchContractHistory returns the instantiation of the contract, migrations are not faked
+func (f *Fake) FetchContractHistory(_ context.Context, address string, _ *query.PageRequest) (*wasmtypes.QueryContractHistoryResponse, error) {
+	contract, err := f.contract("FetchContractHistory", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wasmtypes.QueryContractHistoryResponse{Entries: []wasmtypes.ContractCodeHistoryEntry{{
+		Operation: wasmtypes.ContractCodeHistoryOperationTypeInit,
+		CodeID:    contract.CodeID,
+		Msg:       contract.InitMsg,
+	}}}, nil
+}
+
+func (f *Fake) FetchContractsByCode(_ context.Context, codeID uint64, _ *query.PageRequest) (*wasmtypes.QueryContractsByCodeResponse, error) {
+	err := f.check("FetchContractsByCode")
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &wasmtypes.QueryContractsByCodeResponse{}
+	for address, contract := range f.contracts {
+		if contract.CodeID == codeID {
+			resp.Contracts = append(resp.Contracts, address)
+		}
+	}
+	sort.Strings(resp.Contracts)
+
+	return resp, nil
+}
+
+func (f *Fake) FetchAllContractsState(_ context.Context, address string, _ *query.PageRequest) (*wasmtypes.QueryAllContractStateResponse, error) {
+	contract, err := f.contract("FetchAllContractsState", address)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &wasmtypes.QueryAllContractStateResponse{}
+	for key, value := range contract.State {
+		resp.Models = append(resp.Models, wasmtypes.Model{Key: []byte(key), Value: value})
+	}
+	sort.Slice(resp.Models, func(i, j int) bool {
+		return string(resp.Models[i].Key) < string(resp.Models[j].Key)
+	})
+
+	return resp, nil
+}
+
+func (f *Fake) RawContractState(_ context.Context, contractAddress string, queryData []byte) (*wasmtypes.QueryRawContractStateResponse, error) {
+	contract, err := f.contract("RawContractState", contractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &wasmtypes.QueryRawContractStateResponse{Data: contract.State[string(queryData)]}, nil
+}
+
+// SmartContractState answers the query with the query handler of the contract
+func (f *Fake) SmartContractState(ctx context.Context, contractAddress string, queryData []byte) (*wasmtypes.QuerySmartContractStateResponse, error) {
+	contract, err := f.contract("SmartContractState", contractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if contract.Query == nil {
+		return nil, sdkerrors.Wrapf(wasmtypes.ErrQueryFailed, "contract %s has no query handler", contractAddress)
+	}
+
+	data, err := contract.Query(ctx, contractAddress, queryData)
+	if err != nil {
+		return nil, sdkerrors.Wrap(wasmtypes.ErrQueryFailed, err.Error())
+	}
+
+	return &wasmtypes.QuerySmartContractStateResponse{Data: data}, nil
+}
+
+func (f *Fake) FetchCode(_ context.Context, codeID uint64) (*wasmtypes.QueryCodeResponse, error) {
+	err := f.check("FetchCode")
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	code, ok := f.codes[codeID]
+	if !ok {
+		return nil, sdkerrors.Wrapf(wasmtypes.ErrNotFound, "code %d", codeID)
+	}
+	info := codeInfo(codeID, code)
+
+	return &wasmtypes.QueryCodeResponse{CodeInfoResponse: &info, Data: code.Wasm}, nil
+}
+
+func (f *Fake) FetchCodes(_ context.Context, _ *query.PageRequest) (*wasmtypes.QueryCodesResponse, error) {
+	err := f.check("FetchCodes")
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	resp := &wasmtypes.QueryCodesResponse{}
+	for _, codeID := range f.codeIDs(false) {
+		resp.CodeInfos = append(resp.CodeInfos, codeInfo(codeID, f.codes[codeID]))
+	}
+
+	return resp, nil
+}
+
+func (f *Fake) FetchPinnedCodes(_ context.Context, _ *query.PageRequest) (*wasmtypes.QueryPinnedCodesResponse, error) {
+	err := f.check("FetchPinnedCodes")
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return &wasmtypes.QueryPinnedCodesResponse{CodeIDs: f.codeIDs(true)}, nil
+}
+
+// contract returns the contract queried by the method
+func (f *Fake) contract(method, address string) (*Contract, error) {
+	err := f.check(method)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	contract, ok := f.contracts[address]
+	if !ok {
+		return nil, sdkerrors.Wrapf(wasmtypes.ErrNotFound, "contract %s", address)
+	}
+
+	return contract, nil
+}
+
+// codeIDs returns sorted IDs of the codes, only of the pinned ones when pinned is set
+func (f *Fake) codeIDs(pinned bool) []uint64 {
+	var ids []uint64
+	for codeID, code := range f.codes {
+		if !pinned || code.Pinned {
+			ids = append(ids, codeID)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+func codeInfo(codeID uint64, code *Code) wasmtypes.CodeInfoResponse {
+	hash := sha256.Sum256(code.Wasm)
+	return wasmtypes.CodeInfoResponse{CodeID: codeID, DataHash: hash[:]}
+}
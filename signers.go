@@ -0,0 +1,418 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	cosmosTypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Signer signs txs on behalf of a single account. The keyring is the default implementation,
+// remote implementations allow keeping private keys outside of the application process
+type Signer interface {
+	// PubKey returns public key of the account
+	PubKey() cryptotypes.PubKey
+	// Address returns address of the account
+	Address() cosmosTypes.AccAddress
+	// Sign signs the sign bytes of a tx
+	Sign(ctx context.Context, signBytes []byte) ([]byte, error)
+}
+
+// keyringSigner signs with a key of the keyring
+type keyringSigner struct {
+	kr     keyring.Keyring
+	name   string
+	pubKey cryptotypes.PubKey
+}
+
+// NewKeyringSigner returns Signer using the named key of the keyring
+func NewKeyringSigner(kr keyring.Keyring, name string) (Signer, error) {
+	info, err := kr.Key(name)
+	if err != nil {
+		return nil, fmt.Errorf("Keyring.Key: %w", err)
+	}
+
+	return &keyringSigner{kr: kr, name: name, pubKey: info.GetPubKey()}, nil
+}
+
+func (s *keyringSigner) PubKey() cryptotypes.PubKey {
+	return s.pubKey
+}
+
+func (s *keyringSigner) Address() cosmosTypes.AccAddress {
+	return cosmosTypes.AccAddress(s.pubKey.Address())
+}
+
+func (s *keyringSigner) Sign(_ context.Context, signBytes []byte) ([]byte, error) {
+	sig, _, err := s.kr.Sign(s.name, signBytes)
+	return sig, err
+}
+
+// newSigner wraps the signer implementation. EVM address is empty for keys other than secp256k1
+func newSigner(name string, key Signer) signer {
+	evmAddress, _ := EVMAddress(key.PubKey())
+
+	return signer{
+		address:    key.Address(),
+		evmAddress: evmAddress,
+		name:       name,
+		key:        key,
+	}
+}
+
+// newKeyringSigner returns signer of the named keyring key
+func (c *Client) newKeyringSigner(name string) (signer, error) {
+	key, err := NewKeyringSigner(c.clientCtx.Keyring, name)
+	if err != nil {
+		return signer{}, err
+	}
+
+	return newSigner(name, key), nil
+}
+
+// isKeyring reports whether the signer key is stored in the client keyring
+func (s signer) isKeyring() bool {
+	_, ok := s.key.(*keyringSigner)
+	return ok
+}
+
+// SignerInfo describes an added signer
+type SignerInfo struct {
+	Name    string
+	Address string
+	// EVMAddress is the 0x address of the signer key, empty for keys other than secp256k1
+	EVMAddress string
+}
+
+func (s signer) info() SignerInfo {
+	return SignerInfo{Name: s.name, Address: s.address.String(), EVMAddress: s.evmAddress}
+}
+
+// signerEntry guards a signer, so it is not rotated or removed while a tx is signed with it
+type signerEntry struct {
+	mu      sync.RWMutex
+	signer  signer
+	removed bool
+}
+
+// signerRegistry is a concurrency safe set of signers
+type signerRegistry struct {
+	mu      sync.RWMutex
+	entries map[string]*signerEntry
+}
+
+func newSignerRegistry() *signerRegistry {
+	return &signerRegistry{entries: make(map[string]*signerEntry)}
+}
+
+// add registers a new signer, failing on duplicate names
+func (r *signerRegistry) add(sgn signer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[sgn.name]; ok {
+		return fmt.Errorf("duplicate signer %s", sgn.name)
+	}
+	r.entries[sgn.name] = &signerEntry{signer: sgn}
+
+	return nil
+}
+
+// reserve claims the name for a signer being created, so concurrent adds of the name fail before creating keys.
+// The returned entry is locked until commit or cancel
+func (r *signerRegistry) reserve(name string) (*signerEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.entries[name]; ok {
+		return nil, fmt.Errorf("duplicate signer %s", name)
+	}
+	e := &signerEntry{signer: signer{name: name}}
+	e.mu.Lock()
+	r.entries[name] = e
+
+	return e, nil
+}
+
+// commit sets the signer of the reserved entry and unlocks it
+func (r *signerRegistry) commit(e *signerEntry, sgn signer) {
+	e.signer = sgn
+	e.mu.Unlock()
+}
+
+// cancel frees the reserved name and unlocks the entry
+func (r *signerRegistry) cancel(e *signerEntry) {
+	r.remove(e)
+	e.mu.Unlock()
+}
+
+func (r *signerRegistry) entry(name string) (*signerEntry, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	e, ok := r.entries[name]
+	if !ok {
+		return nil, fmt.Errorf("signer with name %s not added", name)
+	}
+
+	return e, nil
+}
+
+// get returns a snapshot of the signer
+func (r *signerRegistry) get(name string) (signer, error) {
+	e, err := r.entry(name)
+	if err != nil {
+		return signer{}, err
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.removed {
+		return signer{}, fmt.Errorf("signer with name %s not added", name)
+	}
+
+	return e.signer, nil
+}
+
+// acquire returns the signer and keeps it from being rotated or removed until release is called
+func (r *signerRegistry) acquire(name string) (sgn signer, release func(), err error) {
+	e, err := r.entry(name)
+	if err != nil {
+		return signer{}, nil, err
+	}
+
+	e.mu.RLock()
+	if e.removed {
+		e.mu.RUnlock()
+		return signer{}, nil, fmt.Errorf("signer with name %s not added", name)
+	}
+
+	return e.signer, e.mu.RUnlock, nil
+}
+
+// lock waits for in-flight txs of the signer to finish and locks it exclusively
+func (r *signerRegistry) lock(name string) (*signerEntry, error) {
+	e, err := r.entry(name)
+	if err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	if e.removed {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("signer with name %s not added", name)
+	}
+
+	return e, nil
+}
+
+// remove deletes the locked entry from the registry
+func (r *signerRegistry) remove(e *signerEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.entries[e.signer.name] == e {
+		delete(r.entries, e.signer.name)
+	}
+	e.removed = true
+}
+
+// list returns every signer sorted by name
+func (r *signerRegistry) list() []signer {
+	r.mu.RLock()
+	entries := make([]*signerEntry, 0, len(r.entries))
+	for _, e := range r.entries {
+		entries = append(entries, e)
+	}
+	r.mu.RUnlock()
+
+	res := make([]signer, 0, len(entries))
+	for _, e := range entries {
+		e.mu.RLock()
+		if !e.removed {
+			res = append(res, e.signer)
+		}
+		e.mu.RUnlock()
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].name < res[j].name })
+
+	return res
+}
+
+func (r *signerRegistry) len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.entries)
+}
+
+// ListSigners returns name and address of every added signer sorted by name
+func (c *Client) ListSigners() []SignerInfo {
+	signers := c.signers.list()
+
+	res := make([]SignerInfo, 0, len(signers))
+	for _, s := range signers {
+		res = append(res, s.info())
+	}
+
+	return res
+}
+
+// GetSigner returns name and addresses of the signer
+func (c *Client) GetSigner(name string) (SignerInfo, error) {
+	sgn, err := c.getSigner(name)
+	if err != nil {
+		return SignerInfo{}, err
+	}
+
+	return sgn.info(), nil
+}
+
+// GetSignerPubKey returns public key of the signer
+func (c *Client) GetSignerPubKey(name string) (cryptotypes.PubKey, error) {
+	sgn, err := c.getSigner(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return sgn.key.PubKey(), nil
+}
+
+// GetSignerByEVMAddress returns signer with the EVM address, the address is matched case-insensitively
+func (c *Client) GetSignerByEVMAddress(evmAddress string) (SignerInfo, error) {
+	for _, s := range c.signers.list() {
+		if s.evmAddress != "" && strings.EqualFold(s.evmAddress, evmAddress) {
+			return s.info(), nil
+		}
+	}
+
+	return SignerInfo{}, fmt.Errorf("signer with EVM address %s not added", evmAddress)
+}
+
+// RemoveSigner removes signer and its key. It waits for txs being signed by the signer to finish
+func (c *Client) RemoveSigner(name string) error {
+	e, err := c.signers.lock(name)
+	if err != nil {
+		return err
+	}
+	defer e.mu.Unlock()
+
+	if e.signer.isKeyring() {
+		err = c.clientCtx.Keyring.Delete(name)
+		if err != nil {
+			return fmt.Errorf("Keyring.Delete: %w", err)
+		}
+	}
+	c.signers.remove(e)
+
+	return nil
+}
+
+// ReplaceSigner replaces key of an added signer with a key derived from the mnemonic, so keys can be rotated
+// without restarting the client. It waits for txs being signed by the signer to finish and returns the new address
+func (c *Client) ReplaceSigner(name, mnemonic string) (string, error) {
+	return c.ReplaceSignerWithOptions(name, mnemonic, KeyOptions{})
+}
+
+// ReplaceSignerWithOptions works as ReplaceSigner, deriving the new key with the given HD options
+func (c *Client) ReplaceSignerWithOptions(name, mnemonic string, opts KeyOptions) (string, error) {
+	if mnemonic == "" {
+		return "", errors.New("empty mnemonic")
+	}
+
+	e, err := c.signers.lock(name)
+	if err != nil {
+		return "", err
+	}
+	defer e.mu.Unlock()
+
+	var sgn signer
+	if e.signer.isKeyring() {
+		sgn, err = c.replaceKey(name, func() (signer, error) {
+			return c.newMnemonicKey(name, mnemonic, opts)
+		})
+	} else {
+		sgn, err = c.newMnemonicKey(name, mnemonic, opts)
+	}
+	if err != nil {
+		return "", err
+	}
+	e.signer = sgn
+
+	return sgn.address.String(), nil
+}
+
+// AddExternalSigner adds signer backed by a custom Signer implementation, e.g. RemoteSigner
+func (c *Client) AddExternalSigner(name string, key Signer) (string, error) {
+	if name == "" {
+		return "", errors.New("empty name")
+	}
+	if key == nil {
+		return "", errors.New("nil signer")
+	}
+
+	sgn := newSigner(name, key)
+	err := c.signers.add(sgn)
+	if err != nil {
+		return "", err
+	}
+
+	return sgn.address.String(), nil
+}
+
+// ReplaceExternalSigner replaces key of an added signer with a custom Signer implementation.
+// It waits for txs being signed by the signer to finish
+func (c *Client) ReplaceExternalSigner(name string, key Signer) (string, error) {
+	if key == nil {
+		return "", errors.New("nil signer")
+	}
+
+	e, err := c.signers.lock(name)
+	if err != nil {
+		return "", err
+	}
+	defer e.mu.Unlock()
+
+	if e.signer.isKeyring() {
+		err = c.clientCtx.Keyring.Delete(name)
+		if err != nil {
+			return "", fmt.Errorf("Keyring.Delete: %w", err)
+		}
+	}
+	e.signer = newSigner(name, key)
+
+	return e.signer.address.String(), nil
+}
+
+// replaceKey deletes the key and creates a new one, restoring the old key if creation fails
+func (c *Client) replaceKey(name string, create func() (signer, error)) (signer, error) {
+	const backupPassphrase = "rotation-backup"
+
+	backup, err := c.clientCtx.Keyring.ExportPrivKeyArmor(name, backupPassphrase)
+	if err != nil {
+		return signer{}, fmt.Errorf("ExportPrivKeyArmor: %w", err)
+	}
+
+	err = c.clientCtx.Keyring.Delete(name)
+	if err != nil {
+		return signer{}, fmt.Errorf("Keyring.Delete: %w", err)
+	}
+
+	sgn, err := create()
+	if err != nil {
+		restoreErr := c.clientCtx.Keyring.ImportPrivKey(name, backup, backupPassphrase)
+		if restoreErr != nil {
+			return signer{}, fmt.Errorf("%w, restore old key: %s", err, restoreErr)
+		}
+
+		return signer{}, err
+	}
+
+	return sgn, nil
+}
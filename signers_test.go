@@ -0,0 +1,176 @@
+package sdk
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"gotest.tools/assert"
+)
+
+const (
+	testMnemonic1 = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	testMnemonic2 = "test test test test test test test test test test test junk"
+	testMnemonic3 = "legal winner thank year wave sausage worth useful legal winner thank yellow"
+)
+
+// newKeyringClient creates client with in-memory keyring and no network connections
+func newKeyringClient() *Client {
+	return &Client{
+		clientCtx: client.Context{}.WithKeyring(keyring.NewInMemory()),
+		signers:   newSignerRegistry(),
+	}
+}
+
+func TestClient_Signers(t *testing.T) {
+	c := newKeyringClient()
+
+	addrB, err := c.AddSigner("b", testMnemonic1)
+	assert.NilError(t, err)
+	addrA, err := c.AddSigner("a", testMnemonic2)
+	assert.NilError(t, err)
+
+	_, err = c.AddSigner("a", testMnemonic1)
+	assert.ErrorContains(t, err, "duplicate signer")
+
+	infoA, err := c.GetSigner("a")
+	assert.NilError(t, err)
+	assert.Equal(t, infoA.Address, addrA)
+	infoB, err := c.GetSigner("b")
+	assert.NilError(t, err)
+	assert.Equal(t, infoB.Address, addrB)
+	assert.DeepEqual(t, c.ListSigners(), []SignerInfo{infoA, infoB})
+
+	newAddr, err := c.ReplaceSigner("a", testMnemonic3)
+	assert.NilError(t, err)
+	assert.Assert(t, newAddr != addrA)
+
+	sgn, err := c.getSigner("a")
+	assert.NilError(t, err)
+	assert.Equal(t, sgn.address.String(), newAddr)
+
+	assert.NilError(t, c.RemoveSigner("b"))
+	_, err = c.getSigner("b")
+	assert.ErrorContains(t, err, "not added")
+	assert.Equal(t, len(c.ListSigners()), 1)
+
+	// the name is free after removal
+	_, err = c.AddSigner("b", testMnemonic1)
+	assert.NilError(t, err)
+}
+
+func TestClient_ReplaceSigner_RestoresOnFailure(t *testing.T) {
+	c := newKeyringClient()
+
+	addr, err := c.AddSigner("a", testMnemonic1)
+	assert.NilError(t, err)
+
+	_, err = c.ReplaceSigner("a", "invalid mnemonic")
+	assert.ErrorContains(t, err, "Invalid mnemonic")
+
+	info, err := c.clientCtx.Keyring.Key("a")
+	assert.NilError(t, err)
+	assert.Equal(t, info.GetAddress().String(), addr)
+}
+
+func TestClient_ReplaceSigner_WaitsForInFlight(t *testing.T) {
+	c := newKeyringClient()
+
+	_, err := c.AddSigner("a", testMnemonic1)
+	assert.NilError(t, err)
+
+	_, release, err := c.acquireSigner("a")
+	assert.NilError(t, err)
+
+	var (
+		mu       sync.Mutex
+		replaced bool
+		done     = make(chan struct{})
+	)
+	go func() {
+		defer close(done)
+		_, err := c.ReplaceSigner("a", testMnemonic2)
+		assert.Check(t, err)
+		mu.Lock()
+		replaced = true
+		mu.Unlock()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	assert.Assert(t, !replaced, "signer was rotated while in use")
+	mu.Unlock()
+
+	release()
+	<-done
+	assert.Assert(t, replaced)
+}
+
+// assertSignerUsable checks that the key of the registered signer is in the keyring and signs
+func assertSignerUsable(t *testing.T, c *Client, name, address string) {
+	sgn, err := c.getSigner(name)
+	assert.NilError(t, err)
+	assert.Equal(t, sgn.address.String(), address)
+
+	info, err := c.clientCtx.Keyring.Key(name)
+	assert.NilError(t, err)
+	assert.Equal(t, info.GetAddress().String(), address)
+
+	sig, err := sgn.key.Sign(context.Background(), []byte("data"))
+	assert.NilError(t, err)
+	assert.Assert(t, sgn.key.PubKey().VerifySignature([]byte("data"), sig))
+}
+
+// slowKeyring widens the window between the duplicate name check and the registration of a new key
+type slowKeyring struct {
+	keyring.Keyring
+}
+
+func (k slowKeyring) NewAccount(uid, mnemonic, passphrase, hdPath string, algo keyring.SignatureAlgo) (keyring.Info, error) {
+	time.Sleep(10 * time.Millisecond)
+	return k.Keyring.NewAccount(uid, mnemonic, passphrase, hdPath, algo)
+}
+
+func (k slowKeyring) ImportPrivKey(uid, armor, passphrase string) error {
+	time.Sleep(10 * time.Millisecond)
+	return k.Keyring.ImportPrivKey(uid, armor, passphrase)
+}
+
+func TestClient_AddSigner_ConcurrentSameName(t *testing.T) {
+	c := newKeyringClient()
+	c.clientCtx = c.clientCtx.WithKeyring(slowKeyring{Keyring: c.clientCtx.Keyring})
+	mnemonics := []string{testMnemonic1, testMnemonic2, testMnemonic3}
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		added   []string
+		dupErrs int
+	)
+	for range 10 {
+		for _, mnemonic := range mnemonics {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				address, err := c.AddSigner("a", mnemonic)
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					assert.Check(t, strings.Contains(err.Error(), "duplicate signer"), err)
+					dupErrs++
+					return
+				}
+				added = append(added, address)
+			}()
+		}
+	}
+	wg.Wait()
+
+	assert.Equal(t, len(added), 1)
+	assert.Equal(t, dupErrs, 29)
+	assertSignerUsable(t, c, "a", added[0])
+}
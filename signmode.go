@@ -0,0 +1,150 @@
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/legacy/legacytx"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+)
+
+// supportedSignModes are the sign modes txs can be signed with
+var supportedSignModes = []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON}
+
+type signModeKey struct{}
+
+// WithSignMode returns context, txs sent with which are signed with the sign mode instead of the client sign mode
+func WithSignMode(ctx context.Context, mode signing.SignMode) context.Context {
+	return context.WithValue(ctx, signModeKey{}, mode)
+}
+
+// factory returns tx factory with the sign mode of the context applied
+func (c *Client) factory(ctx context.Context) tx.Factory {
+	if mode, ok := ctx.Value(signModeKey{}).(signing.SignMode); ok && mode != signing.SignMode_SIGN_MODE_UNSPECIFIED {
+		return c.txFactory.WithSignMode(mode)
+	}
+
+	return c.txFactory
+}
+
+// validateSignMode checks the sign mode is supported, SIGN_MODE_UNSPECIFIED is allowed and means SIGN_MODE_DIRECT
+func validateSignMode(mode signing.SignMode) error {
+	if mode == signing.SignMode_SIGN_MODE_UNSPECIFIED {
+		return nil
+	}
+	for _, m := range supportedSignModes {
+		if m == mode {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unsupported sign mode %s", mode)
+}
+
+// newLegacyAmino returns amino codec with the messages sent by the client registered, needed for SIGN_MODE_LEGACY_AMINO_JSON
+func newLegacyAmino() *codec.LegacyAmino {
+	cdc := codec.NewLegacyAmino()
+	std.RegisterLegacyAminoCodec(cdc)
+	authtypes.RegisterLegacyAminoCodec(cdc)
+	banktypes.RegisterLegacyAminoCodec(cdc)
+	stakingtypes.RegisterLegacyAminoCodec(cdc)
+	wasmtypes.RegisterLegacyAminoCodec(cdc)
+	registerAuthzAmino(cdc)
+
+	return cdc
+}
+
+// newTxConfig returns tx config signing SIGN_MODE_LEGACY_AMINO_JSON with the amino codec
+func newTxConfig(registry codectypes.InterfaceRegistry, cdc *codec.LegacyAmino) client.TxConfig {
+	protoCodec := codec.NewProtoCodec(registry)
+	direct := authtx.NewTxConfig(protoCodec, []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT}).SignModeHandler()
+	handler := authsigning.NewSignModeHandlerMap(supportedSignModes[0], []authsigning.SignModeHandler{direct, aminoJSONHandler{cdc: cdc}})
+
+	return authtx.NewTxConfigWithHandler(protoCodec, handler)
+}
+
+// aminoJSONHandler builds SIGN_MODE_LEGACY_AMINO_JSON sign bytes as the SDK handler does, but authz messages are encoded
+// with the codec instead of the global amino codec, which has no authz messages and the messages they execute registered
+type aminoJSONHandler struct {
+	cdc *codec.LegacyAmino
+}
+
+func (h aminoJSONHandler) DefaultMode() signing.SignMode {
+	return signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+}
+
+func (h aminoJSONHandler) Modes() []signing.SignMode {
+	return []signing.SignMode{signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON}
+}
+
+func (h aminoJSONHandler) GetSignBytes(mode signing.SignMode, data authsigning.SignerData, sdkTx sdktypes.Tx) ([]byte, error) {
+	if mode != signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON {
+		return nil, fmt.Errorf("expected %s, got %s", signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, mode)
+	}
+	txn, ok := sdkTx.(authsigning.Tx)
+	if !ok {
+		return nil, fmt.Errorf("expected %T, got %T", (authsigning.Tx)(nil), sdkTx)
+	}
+	if ext, ok := sdkTx.(interface {
+		GetExtensionOptions() []*codectypes.Any
+		GetNonCriticalExtensionOptions() []*codectypes.Any
+	}); ok && (len(ext.GetExtensionOptions()) != 0 || len(ext.GetNonCriticalExtensionOptions()) != 0) {
+		return nil, errors.New("SIGN_MODE_LEGACY_AMINO_JSON does not support protobuf extension options")
+	}
+
+	msgs := make([]json.RawMessage, 0, len(txn.GetMsgs()))
+	for _, msg := range txn.GetMsgs() {
+		switch msg := msg.(type) {
+		case *authz.MsgGrant, *authz.MsgRevoke, *authz.MsgExec:
+			bz, err := h.cdc.MarshalJSON(msg)
+			if err != nil {
+				return nil, fmt.Errorf("MarshalJSON %T: %w", msg, err)
+			}
+			msgs = append(msgs, sdktypes.MustSortJSON(bz))
+		case legacytx.LegacyMsg:
+			msgs = append(msgs, msg.GetSignBytes())
+		default:
+			return nil, fmt.Errorf("%T does not support amino JSON", msg)
+		}
+	}
+
+	bz, err := h.cdc.MarshalJSON(legacytx.StdSignDoc{
+		AccountNumber: data.AccountNumber,
+		ChainID:       data.ChainID,
+		Fee:           legacytx.StdFee{Amount: txn.GetFee(), Gas: txn.GetGas()}.Bytes(),
+		Memo:          txn.GetMemo(),
+		Msgs:          msgs,
+		Sequence:      data.Sequence,
+		TimeoutHeight: txn.GetTimeoutHeight(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("MarshalJSON sign doc: %w", err)
+	}
+
+	return sdktypes.SortJSON(bz)
+}
+
+// registerAuthzAmino registers authz messages and authorizations, authz of the SDK fork has no amino registration
+func registerAuthzAmino(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(&authz.MsgGrant{}, "cosmos-sdk/MsgGrant", nil)
+	cdc.RegisterConcrete(&authz.MsgRevoke{}, "cosmos-sdk/MsgRevoke", nil)
+	cdc.RegisterConcrete(&authz.MsgExec{}, "cosmos-sdk/MsgExec", nil)
+	cdc.RegisterConcrete(&authz.GenericAuthorization{}, "cosmos-sdk/GenericAuthorization", nil)
+	cdc.RegisterConcrete(&banktypes.SendAuthorization{}, "cosmos-sdk/SendAuthorization", nil)
+	cdc.RegisterConcrete(&stakingtypes.StakeAuthorization{}, "cosmos-sdk/StakeAuthorization", nil)
+}
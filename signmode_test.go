@@ -0,0 +1,121 @@
+package sdk
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"gotest.tools/assert"
+)
+
+func TestConfig_ValidateSignMode(t *testing.T) {
+	cfg := Config{ChainID: testChainID, GRPCHost: "localhost:9090", RPCHost: "http://localhost:26657"}
+	assert.NilError(t, cfg.Validate())
+
+	cfg.SignMode = signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+	assert.NilError(t, cfg.Validate())
+
+	cfg.SignMode = signing.SignMode_SIGN_MODE_TEXTUAL
+	assert.ErrorContains(t, cfg.Validate(), "unsupported sign mode")
+}
+
+func TestClient_SignTx_SignMode(t *testing.T) {
+	c := newTxClient()
+
+	addr, err := c.AddSigner("signer", testMnemonic1)
+	assert.NilError(t, err)
+	sgn, err := c.getSigner("signer")
+	assert.NilError(t, err)
+	from := sdktypes.MustAccAddressFromBech32(addr)
+
+	for _, mode := range supportedSignModes {
+		ctx := WithSignMode(context.Background(), mode)
+		txf := c.factory(ctx).WithAccountNumber(1).WithSequence(2)
+		assert.Equal(t, txf.SignMode(), mode)
+
+		txn, err := txf.BuildUnsignedTx(banktypes.NewMsgSend(from, from, sdktypes.NewCoins(sdktypes.NewInt64Coin(DefaultDenom, 1))))
+		assert.NilError(t, err)
+		assert.NilError(t, c.signTx(ctx, txf, sgn.key, txn))
+
+		sigs, err := txn.GetTx().GetSignaturesV2()
+		assert.NilError(t, err)
+		assert.Equal(t, sigs[0].Data.(*signing.SingleSignatureData).SignMode, mode)
+		assert.NilError(t, authsigning.VerifySignature(sgn.key.PubKey(), authsigning.SignerData{
+			ChainID:       testChainID,
+			AccountNumber: 1,
+			Sequence:      2,
+		}, sigs[0].Data, c.clientCtx.TxConfig.SignModeHandler(), txn.GetTx()))
+	}
+
+	// the client sign mode is used without override
+	assert.Equal(t, c.factory(context.Background()).SignMode(), signing.SignMode_SIGN_MODE_DIRECT)
+}
+
+func TestNewLegacyAmino(t *testing.T) {
+	bz, err := newLegacyAmino().MarshalJSON(&wasmtypes.MsgExecuteContract{Sender: "sender", Contract: "contract", Msg: []byte(`{}`)})
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(bz), `"type":"wasm/MsgExecuteContract"`), string(bz))
+}
+
+func TestClient_SignTx_AuthzLegacyAmino(t *testing.T) {
+	c := newTxClient()
+
+	addr, err := c.AddSigner("signer", testMnemonic1)
+	assert.NilError(t, err)
+	sgn, err := c.getSigner("signer")
+	assert.NilError(t, err)
+	from := sdktypes.MustAccAddressFromBech32(addr)
+
+	exec := authz.NewMsgExec(from, []sdktypes.Msg{banktypes.NewMsgSend(from, from, sdktypes.NewCoins(sdktypes.NewInt64Coin(DefaultDenom, 1)))})
+	ctx := WithSignMode(context.Background(), signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+	txf := c.factory(ctx).WithAccountNumber(1).WithSequence(2)
+	txn, err := txf.BuildUnsignedTx(&exec)
+	assert.NilError(t, err)
+
+	signerData := authsigning.SignerData{ChainID: testChainID, AccountNumber: 1, Sequence: 2}
+	signBytes, err := c.clientCtx.TxConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, signerData, txn.GetTx())
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(signBytes), `"type":"cosmos-sdk/MsgExec"`), string(signBytes))
+	assert.Assert(t, strings.Contains(string(signBytes), `"type":"cosmos-sdk/MsgSend"`), string(signBytes))
+
+	assert.NilError(t, c.signTx(ctx, txf, sgn.key, txn))
+	sigs, err := txn.GetTx().GetSignaturesV2()
+	assert.NilError(t, err)
+	assert.NilError(t, authsigning.VerifySignature(sgn.key.PubKey(), signerData, sigs[0].Data, c.clientCtx.TxConfig.SignModeHandler(), txn.GetTx()))
+}
+
+func TestAminoJSONHandler_MatchesSDK(t *testing.T) {
+	c := newTxClient()
+
+	from := sdktypes.AccAddress("from________________")
+	send := banktypes.NewMsgSend(from, from, sdktypes.NewCoins(sdktypes.NewInt64Coin(DefaultDenom, 1)))
+	txf := c.factory(WithSignMode(context.Background(), signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)).WithMemo("memo")
+	txn, err := txf.BuildUnsignedTx(send)
+	assert.NilError(t, err)
+
+	signerData := authsigning.SignerData{ChainID: testChainID, AccountNumber: 1, Sequence: 2}
+	got, err := c.clientCtx.TxConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, signerData, txn.GetTx())
+	assert.NilError(t, err)
+
+	sdkConfig := authtx.NewTxConfig(codec.NewProtoCodec(c.clientCtx.InterfaceRegistry), supportedSignModes)
+	want, err := sdkConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, signerData, txn.GetTx())
+	assert.NilError(t, err)
+	assert.Equal(t, string(got), string(want))
+}
+
+func TestNewLegacyAmino_GlobalCodecUntouched(t *testing.T) {
+	_ = newTxClient()
+
+	bz, err := legacy.Cdc.MarshalJSON(&authz.MsgExec{})
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(bz), "cosmos-sdk/MsgExec"), string(bz))
+}
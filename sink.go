@@ -0,0 +1,144 @@
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+)
+
+// Sink persists indexed events. Implementations must write a block atomically,
+// so after a restart LastHeight never points into a partially written block.
+type Sink interface {
+	// WriteBlock persists events of the height and marks every height up to it as indexed.
+	// It is also called with no events to advance the checkpoint over heights without txs.
+	WriteBlock(ctx context.Context, height int64, events []IndexedEvent) error
+	// LastHeight returns the last indexed height, 0 if nothing was indexed yet
+	LastHeight(ctx context.Context) (int64, error)
+	// Close releases resources held by the sink
+	Close() error
+}
+
+// IndexedEvent is a tx event prepared for persistence
+type IndexedEvent struct {
+	Height     int64            `json:"height"`
+	TxHash     string           `json:"tx_hash"`
+	TxIndex    uint32           `json:"tx_index"`
+	EventIndex int              `json:"event_index"`
+	Type       string           `json:"type"`
+	Attributes []EventAttribute `json:"attributes"`
+}
+
+// EventAttribute is a key value pair of an event. Keys are not unique within an event
+type EventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// Attribute returns the value of the first attribute with the key
+func (e IndexedEvent) Attribute(key string) (string, bool) {
+	for _, a := range e.Attributes {
+		if a.Key == key {
+			return a.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// NewIndexedEvents converts events of the tx, keeping only event types from the filter if it is not empty
+func NewIndexedEvents(tx *coretypes.ResultTx, eventTypes ...string) []IndexedEvent {
+	hash := strings.ToUpper(fmt.Sprintf("%x", tx.Hash))
+
+	var events []IndexedEvent
+	for i, event := range tx.TxResult.Events {
+		if len(eventTypes) > 0 && !containsString(eventTypes, event.Type) {
+			continue
+		}
+
+		events = append(events, IndexedEvent{
+			Height:     tx.Height,
+			TxHash:     hash,
+			TxIndex:    tx.Index,
+			EventIndex: i,
+			Type:       event.Type,
+			Attributes: newEventAttributes(event.Attributes),
+		})
+	}
+
+	return events
+}
+
+func newEventAttributes(attributes []abci.EventAttribute) []EventAttribute {
+	res := make([]EventAttribute, 0, len(attributes))
+	for _, a := range attributes {
+		res = append(res, EventAttribute{Key: string(a.Key), Value: string(a.Value)})
+	}
+
+	return res
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MemorySink keeps indexed events in memory. It is meant for tests and short-lived tools
+type MemorySink struct {
+	mu         sync.RWMutex
+	events     []IndexedEvent
+	lastHeight int64
+}
+
+// NewMemorySink creates an empty in-memory sink
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (s *MemorySink) WriteBlock(_ context.Context, height int64, events []IndexedEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if height <= s.lastHeight {
+		return fmt.Errorf("height %d is already indexed, last height %d", height, s.lastHeight)
+	}
+
+	s.events = append(s.events, events...)
+	s.lastHeight = height
+
+	return nil
+}
+
+func (s *MemorySink) LastHeight(_ context.Context) (int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.lastHeight, nil
+}
+
+func (s *MemorySink) Close() error {
+	return nil
+}
+
+// Events returns a copy of every written event, filtered by type if it is not empty
+func (s *MemorySink) Events(eventType string) []IndexedEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]IndexedEvent, 0, len(s.events))
+	for _, e := range s.events {
+		if eventType == "" || e.Type == eventType {
+			res = append(res, e)
+		}
+	}
+
+	return res
+}
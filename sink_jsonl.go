@@ -0,0 +1,154 @@
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// jsonlCheckpoint is stored next to the JSONL file. Offset is the size of the data file after the checkpointed block
+type jsonlCheckpoint struct {
+	Height int64 `json:"height"`
+	Offset int64 `json:"offset"`
+}
+
+// JSONLSink appends indexed events to a file, one JSON object per line.
+// The checkpoint is kept in a "<path>.checkpoint" file, data written after the checkpoint is truncated on open.
+// A non-empty file without a checkpoint is not opened.
+type JSONLSink struct {
+	mu         sync.Mutex
+	file       *os.File
+	path       string
+	checkpoint jsonlCheckpoint
+}
+
+// NewJSONLSink opens or creates JSONL sink at the path
+func NewJSONLSink(path string) (*JSONLSink, error) {
+	s := &JSONLSink{path: path}
+
+	raw, err := os.ReadFile(s.checkpointPath())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("ReadFile: %w", err)
+	}
+	if len(raw) > 0 {
+		err = json.Unmarshal(raw, &s.checkpoint)
+		if err != nil {
+			return nil, fmt.Errorf("Unmarshal checkpoint: %w", err)
+		}
+	}
+
+	s.file, err = os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("OpenFile: %w", err)
+	}
+
+	// data without a checkpoint is not written by the sink or the checkpoint is lost, truncating would wipe it
+	if len(raw) == 0 {
+		info, err := s.file.Stat()
+		if err != nil {
+			s.file.Close()
+			return nil, fmt.Errorf("Stat: %w", err)
+		}
+		if info.Size() > 0 {
+			s.file.Close()
+			return nil, fmt.Errorf("%s is not empty, but has no checkpoint %s", path, s.checkpointPath())
+		}
+	}
+
+	// drop a partially written block
+	err = s.file.Truncate(s.checkpoint.Offset)
+	if err != nil {
+		s.file.Close()
+		return nil, fmt.Errorf("Truncate: %w", err)
+	}
+	_, err = s.file.Seek(s.checkpoint.Offset, io.SeekStart)
+	if err != nil {
+		s.file.Close()
+		return nil, fmt.Errorf("Seek: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *JSONLSink) checkpointPath() string {
+	return s.path + ".checkpoint"
+}
+
+func (s *JSONLSink) WriteBlock(_ context.Context, height int64, events []IndexedEvent) (err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if height <= s.checkpoint.Height {
+		return fmt.Errorf("height %d is already indexed, last height %d", height, s.checkpoint.Height)
+	}
+
+	defer func() {
+		if err != nil {
+			// roll back to the last checkpoint, so the next write does not follow a partial block
+			_ = s.file.Truncate(s.checkpoint.Offset)
+			_, _ = s.file.Seek(s.checkpoint.Offset, io.SeekStart)
+		}
+	}()
+
+	w := bufio.NewWriter(s.file)
+	enc := json.NewEncoder(w)
+	for i := range events {
+		err = enc.Encode(events[i])
+		if err != nil {
+			return fmt.Errorf("Encode: %w", err)
+		}
+	}
+	err = w.Flush()
+	if err != nil {
+		return fmt.Errorf("Flush: %w", err)
+	}
+	err = s.file.Sync()
+	if err != nil {
+		return fmt.Errorf("Sync: %w", err)
+	}
+
+	offset, err := s.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("Seek: %w", err)
+	}
+
+	checkpoint := jsonlCheckpoint{Height: height, Offset: offset}
+	raw, err := json.Marshal(checkpoint)
+	if err != nil {
+		return fmt.Errorf("Marshal checkpoint: %w", err)
+	}
+
+	// rename is atomic, so the checkpoint is never half written
+	tmpPath := s.checkpointPath() + ".tmp"
+	err = os.WriteFile(tmpPath, raw, 0o644)
+	if err != nil {
+		return fmt.Errorf("WriteFile: %w", err)
+	}
+	err = os.Rename(tmpPath, s.checkpointPath())
+	if err != nil {
+		return fmt.Errorf("Rename: %w", err)
+	}
+
+	s.checkpoint = checkpoint
+
+	return nil
+}
+
+func (s *JSONLSink) LastHeight(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.checkpoint.Height, nil
+}
+
+func (s *JSONLSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
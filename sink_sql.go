@@ -0,0 +1,190 @@
+package sdk
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const (
+	// SQLDialectGeneric uses "?" placeholders, e.g. SQLite and MySQL
+	SQLDialectGeneric SQLDialect = iota
+	// SQLDialectPostgres uses "$n" placeholders
+	SQLDialectPostgres
+)
+
+type (
+	// SQLDialect selects placeholder style of SQLSink queries
+	SQLDialect int
+
+	// SQLSinkOptions configures SQLSink
+	SQLSinkOptions struct {
+		Dialect SQLDialect
+		// TablePrefix is prepended to every table name
+		TablePrefix string
+	}
+)
+
+// SQLSink writes indexed events to a database/sql database, one table per event type.
+// Every block is written in a single transaction together with the checkpoint, event tables are created
+// before the transaction, as MySQL commits DDL statements implicitly.
+type SQLSink struct {
+	db   *sql.DB
+	opts SQLSinkOptions
+
+	mu     sync.Mutex
+	tables map[string]struct{}
+}
+
+// NewSQLSink creates checkpoint table if needed and returns SQL sink. The caller keeps ownership of db
+func NewSQLSink(ctx context.Context, db *sql.DB, opts SQLSinkOptions) (*SQLSink, error) {
+	if db == nil {
+		return nil, errors.New("nil db")
+	}
+
+	s := &SQLSink{
+		db:     db,
+		opts:   opts,
+		tables: make(map[string]struct{}),
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (id INTEGER PRIMARY KEY, height BIGINT NOT NULL)`, s.checkpointTable()))
+	if err != nil {
+		return nil, fmt.Errorf("create checkpoint table: %w", err)
+	}
+
+	return s, nil
+}
+
+func (s *SQLSink) checkpointTable() string {
+	return s.opts.TablePrefix + "checkpoint"
+}
+
+// EventTable returns table name used for the event type
+func (s *SQLSink) EventTable(eventType string) string {
+	var b strings.Builder
+	b.WriteString(s.opts.TablePrefix)
+	b.WriteString("event_")
+	for _, r := range strings.ToLower(eventType) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune('_')
+		}
+	}
+
+	return b.String()
+}
+
+func (s *SQLSink) placeholders(n int) string {
+	res := make([]string, n)
+	for i := range res {
+		if s.opts.Dialect == SQLDialectPostgres {
+			res[i] = fmt.Sprintf("$%d", i+1)
+		} else {
+			res[i] = "?"
+		}
+	}
+
+	return strings.Join(res, ", ")
+}
+
+func (s *SQLSink) WriteBlock(ctx context.Context, height int64, events []IndexedEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	err := s.createEventTables(ctx, events)
+	if err != nil {
+		return err
+	}
+
+	dbTx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("BeginTx: %w", err)
+	}
+	defer dbTx.Rollback() //nolint:errcheck
+
+	var lastHeight int64
+	err = dbTx.QueryRowContext(ctx, fmt.Sprintf(`SELECT height FROM %s WHERE id = 1`, s.checkpointTable())).Scan(&lastHeight)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("select checkpoint: %w", err)
+	}
+	if height <= lastHeight {
+		return fmt.Errorf("height %d is already indexed, last height %d", height, lastHeight)
+	}
+
+	for _, event := range events {
+		table := s.EventTable(event.Type)
+		attributes, err := json.Marshal(event.Attributes)
+		if err != nil {
+			return fmt.Errorf("Marshal attributes: %w", err)
+		}
+
+		_, err = dbTx.ExecContext(ctx,
+			fmt.Sprintf(`INSERT INTO %s (height, tx_hash, tx_index, event_index, attributes) VALUES (%s)`, table, s.placeholders(5)),
+			event.Height, event.TxHash, event.TxIndex, event.EventIndex, string(attributes),
+		)
+		if err != nil {
+			return fmt.Errorf("insert into %s: %w", table, err)
+		}
+	}
+
+	if lastHeight == 0 {
+		_, err = dbTx.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %s (id, height) VALUES (1, %s)`, s.checkpointTable(), s.placeholders(1)), height)
+	} else {
+		_, err = dbTx.ExecContext(ctx, fmt.Sprintf(`UPDATE %s SET height = %s WHERE id = 1`, s.checkpointTable(), s.placeholders(1)), height)
+	}
+	if err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+
+	err = dbTx.Commit()
+	if err != nil {
+		return fmt.Errorf("Commit: %w", err)
+	}
+
+	return nil
+}
+
+// createEventTables creates tables of the event types, which are not created yet
+func (s *SQLSink) createEventTables(ctx context.Context, events []IndexedEvent) error {
+	for _, event := range events {
+		table := s.EventTable(event.Type)
+		if _, ok := s.tables[table]; ok {
+			continue
+		}
+
+		_, err := s.db.ExecContext(ctx, fmt.Sprintf(
+			`CREATE TABLE IF NOT EXISTS %s (height BIGINT NOT NULL, tx_hash VARCHAR(64) NOT NULL, tx_index INTEGER NOT NULL, event_index INTEGER NOT NULL, attributes TEXT NOT NULL)`,
+			table,
+		))
+		if err != nil {
+			return fmt.Errorf("create table %s: %w", table, err)
+		}
+		s.tables[table] = struct{}{}
+	}
+
+	return nil
+}
+
+func (s *SQLSink) LastHeight(ctx context.Context) (int64, error) {
+	var height int64
+	err := s.db.QueryRowContext(ctx, fmt.Sprintf(`SELECT height FROM %s WHERE id = 1`, s.checkpointTable())).Scan(&height)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("select checkpoint: %w", err)
+	}
+
+	return height, nil
+}
+
+// Close does nothing, as the db is owned by the caller
+func (s *SQLSink) Close() error {
+	return nil
+}
@@ -0,0 +1,236 @@
+package sdk
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+var (
+	fakeSQLCreate = regexp.MustCompile(`^CREATE TABLE IF NOT EXISTS (\w+)`)
+	fakeSQLSelect = regexp.MustCompile(`^SELECT height FROM (\w+) WHERE id = 1`)
+	fakeSQLInsert = regexp.MustCompile(`^INSERT INTO (\w+) \(id, height\)|^INSERT INTO (\w+)`)
+	fakeSQLUpdate = regexp.MustCompile(`^UPDATE (\w+) SET height`)
+)
+
+// fakeSQL is an in-memory database/sql driver understanding the statements of SQLSink. Writes of a transaction
+// are applied on commit, DDL statements executed in a transaction are counted, as MySQL commits them implicitly
+type fakeSQL struct {
+	mu     sync.Mutex
+	tables map[string][][]driver.Value
+	// ddlInTx is the amount of DDL statements executed in transactions
+	ddlInTx int
+	// failOn fails statements containing it
+	failOn string
+}
+
+func newFakeSQL() *fakeSQL {
+	return &fakeSQL{tables: make(map[string][][]driver.Value)}
+}
+
+func (db *fakeSQL) Connect(context.Context) (driver.Conn, error) { return &fakeSQLConn{db: db}, nil }
+func (db *fakeSQL) Driver() driver.Driver                        { return nil }
+
+func (db *fakeSQL) rows(table string) int {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return len(db.tables[table])
+}
+
+type fakeSQLConn struct {
+	db *fakeSQL
+	// staged holds tables of the open transaction
+	staged map[string][][]driver.Value
+}
+
+func (c *fakeSQLConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeSQLStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeSQLConn) Close() error { return nil }
+
+func (c *fakeSQLConn) Begin() (driver.Tx, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.staged = make(map[string][][]driver.Value, len(c.db.tables))
+	for table, rows := range c.db.tables {
+		c.staged[table] = append([][]driver.Value(nil), rows...)
+	}
+
+	return c, nil
+}
+
+func (c *fakeSQLConn) Commit() error {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	c.db.tables, c.staged = c.staged, nil
+	return nil
+}
+
+func (c *fakeSQLConn) Rollback() error {
+	c.staged = nil
+	return nil
+}
+
+// exec runs the statement on the transaction tables or on the database
+func (c *fakeSQLConn) exec(query string, args []driver.Value) ([]driver.Value, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+
+	if c.db.failOn != "" && strings.Contains(query, c.db.failOn) {
+		return nil, errors.New("injected failure")
+	}
+
+	tables := c.db.tables
+	if c.staged != nil {
+		tables = c.staged
+	}
+
+	if m := fakeSQLCreate.FindStringSubmatch(query); m != nil {
+		if c.staged != nil {
+			c.db.ddlInTx++
+		}
+		if _, ok := c.db.tables[m[1]]; !ok {
+			c.db.tables[m[1]] = nil
+			if c.staged != nil {
+				c.staged[m[1]] = nil
+			}
+		}
+		return nil, nil
+	}
+	if m := fakeSQLSelect.FindStringSubmatch(query); m != nil {
+		if rows := tables[m[1]]; len(rows) > 0 {
+			return []driver.Value{rows[0][1]}, nil
+		}
+		return nil, nil
+	}
+	if m := fakeSQLUpdate.FindStringSubmatch(query); m != nil {
+		tables[m[1]][0][1] = args[0]
+		return nil, nil
+	}
+	if m := fakeSQLInsert.FindStringSubmatch(query); m != nil {
+		table, row := m[2], args
+		if m[1] != "" {
+			table, row = m[1], []driver.Value{int64(1), args[0]}
+		}
+		if _, ok := tables[table]; !ok {
+			return nil, fmt.Errorf("no such table: %s", table)
+		}
+		tables[table] = append(tables[table], row)
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("unsupported query: %s", query)
+}
+
+type fakeSQLStmt struct {
+	conn  *fakeSQLConn
+	query string
+}
+
+func (s *fakeSQLStmt) Close() error  { return nil }
+func (s *fakeSQLStmt) NumInput() int { return -1 }
+
+func (s *fakeSQLStmt) Exec(args []driver.Value) (driver.Result, error) {
+	_, err := s.conn.exec(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeSQLStmt) Query(args []driver.Value) (driver.Rows, error) {
+	row, err := s.conn.exec(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return &fakeSQLRows{row: row}, nil
+}
+
+type fakeSQLRows struct {
+	row []driver.Value
+}
+
+func (r *fakeSQLRows) Columns() []string { return []string{"height"} }
+func (r *fakeSQLRows) Close() error      { return nil }
+
+func (r *fakeSQLRows) Next(dest []driver.Value) error {
+	if r.row == nil {
+		return io.EOF
+	}
+	copy(dest, r.row)
+	r.row = nil
+
+	return nil
+}
+
+func TestSQLSink_Checkpoint(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeSQL()
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	sink, err := NewSQLSink(ctx, db, SQLSinkOptions{TablePrefix: "sei_"})
+	assert.NilError(t, err)
+
+	last, err := sink.LastHeight(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, last, int64(0))
+
+	assert.NilError(t, sink.WriteBlock(ctx, 10, []IndexedEvent{testEvent(10, "wasm"), testEvent(10, "transfer")}))
+	assert.NilError(t, sink.WriteBlock(ctx, 20, []IndexedEvent{testEvent(20, "wasm")}))
+	assert.ErrorContains(t, sink.WriteBlock(ctx, 15, nil), "already indexed")
+	assert.Equal(t, fake.ddlInTx, 0)
+
+	// resume with a new sink
+	sink, err = NewSQLSink(ctx, db, SQLSinkOptions{TablePrefix: "sei_"})
+	assert.NilError(t, err)
+
+	last, err = sink.LastHeight(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, last, int64(20))
+	assert.ErrorContains(t, sink.WriteBlock(ctx, 20, nil), "already indexed")
+
+	assert.NilError(t, sink.WriteBlock(ctx, 21, []IndexedEvent{testEvent(21, "wasm")}))
+	assert.Equal(t, fake.rows("sei_event_wasm"), 3)
+	assert.Equal(t, fake.rows("sei_event_transfer"), 1)
+}
+
+func TestSQLSink_FailedBlock(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeSQL()
+	db := sql.OpenDB(fake)
+	defer db.Close()
+
+	sink, err := NewSQLSink(ctx, db, SQLSinkOptions{Dialect: SQLDialectPostgres})
+	assert.NilError(t, err)
+
+	fake.failOn = "INSERT INTO event_transfer"
+	err = sink.WriteBlock(ctx, 10, []IndexedEvent{testEvent(10, "wasm"), testEvent(10, "transfer")})
+	assert.ErrorContains(t, err, "injected failure")
+
+	// neither events nor the checkpoint of the block are written
+	last, err := sink.LastHeight(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, last, int64(0))
+	assert.Equal(t, fake.rows("event_wasm"), 0)
+
+	fake.failOn = ""
+	assert.NilError(t, sink.WriteBlock(ctx, 10, []IndexedEvent{testEvent(10, "wasm"), testEvent(10, "transfer")}))
+	assert.Equal(t, fake.rows("event_wasm"), 1)
+	assert.Equal(t, fake.ddlInTx, 0)
+}
@@ -0,0 +1,89 @@
+package sdk
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func testEvent(height int64, eventType string) IndexedEvent {
+	return IndexedEvent{
+		Height:     height,
+		TxHash:     "ABCD",
+		Type:       eventType,
+		Attributes: []EventAttribute{{Key: "_contract_address", Value: "sei1contract"}},
+	}
+}
+
+func TestJSONLSink_Checkpoint(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	sink, err := NewJSONLSink(path)
+	assert.NilError(t, err)
+
+	assert.NilError(t, sink.WriteBlock(ctx, 10, []IndexedEvent{testEvent(10, "wasm"), testEvent(10, "transfer")}))
+	assert.NilError(t, sink.WriteBlock(ctx, 20, nil))
+	assert.ErrorContains(t, sink.WriteBlock(ctx, 15, nil), "already indexed")
+
+	// simulate a crash in the middle of a block
+	_, err = sink.file.WriteString(`{"height":21}` + "\n")
+	assert.NilError(t, err)
+	assert.NilError(t, sink.file.Close())
+
+	sink, err = NewJSONLSink(path)
+	assert.NilError(t, err)
+	defer sink.Close()
+
+	last, err := sink.LastHeight(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, last, int64(20))
+
+	assert.NilError(t, sink.WriteBlock(ctx, 21, []IndexedEvent{testEvent(21, "wasm")}))
+
+	f, err := os.Open(path)
+	assert.NilError(t, err)
+	defer f.Close()
+
+	var lines int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines++
+	}
+	assert.Equal(t, lines, 3)
+}
+
+func TestJSONLSink_MissingCheckpoint(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	data := `{"height":10}` + "\n"
+	assert.NilError(t, os.WriteFile(path, []byte(data), 0o644))
+
+	_, err := NewJSONLSink(path)
+	assert.ErrorContains(t, err, "has no checkpoint")
+
+	raw, err := os.ReadFile(path)
+	assert.NilError(t, err)
+	assert.Equal(t, string(raw), data)
+}
+
+func TestMemorySink(t *testing.T) {
+	ctx := context.Background()
+	sink := NewMemorySink()
+
+	assert.NilError(t, sink.WriteBlock(ctx, 5, []IndexedEvent{testEvent(5, "wasm"), testEvent(5, "transfer")}))
+	assert.NilError(t, sink.WriteBlock(ctx, 7, []IndexedEvent{testEvent(7, "wasm")}))
+
+	last, err := sink.LastHeight(ctx)
+	assert.NilError(t, err)
+	assert.Equal(t, last, int64(7))
+	assert.Equal(t, len(sink.Events("")), 3)
+	assert.Equal(t, len(sink.Events("wasm")), 2)
+
+	value, ok := sink.Events("wasm")[0].Attribute("_contract_address")
+	assert.Assert(t, ok)
+	assert.Equal(t, value, "sei1contract")
+}
@@ -0,0 +1,229 @@
+package sdk
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/std"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/cosmos/cosmos-sdk/x/auth/tx"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/rpc/coretypes"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"gotest.tools/assert"
+)
+
+var stubHeightRegexp = regexp.MustCompile(`tx\.height>=(\d+) AND tx\.height<=(\d+)`)
+
+// stubRPC is a minimal tendermint JSON-RPC server serving tx_search, header and status from a fixed tx set
+type stubRPC struct {
+	mu       sync.Mutex
+	txs      []*coretypes.ResultTx
+	searches []coretypes.RequestTxSearch
+	// headers is the amount of header requests
+	headers int
+	// failures is the amount of upcoming tx_search requests answered with an error
+	failures int
+	// network, height and catchingUp are reported by status
+	network    string
+	height     int64
+	catchingUp bool
+
+	server *httptest.Server
+}
+
+// newStubRPC creates stub serving one tx per height argument, repeated heights produce several txs in a block
+func newStubRPC(t *testing.T, heights ...int64) *stubRPC {
+	s := &stubRPC{}
+	for i, height := range heights {
+		var index uint32
+		if i > 0 && heights[i-1] == height {
+			index = s.txs[i-1].Index + 1
+		}
+
+		s.txs = append(s.txs, &coretypes.ResultTx{
+			Hash:   []byte{byte(i >> 8), byte(i)},
+			Height: height,
+			Index:  index,
+			TxResult: abci.ExecTxResult{
+				Events: []abci.Event{{Type: "wasm", Attributes: []abci.EventAttribute{{Key: []byte("n"), Value: []byte(strconv.Itoa(i))}}}},
+			},
+		})
+	}
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+func (s *stubRPC) handle(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage `json:"id"`
+		Method string          `json:"method"`
+		Params json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result interface{}
+	switch req.Method {
+	case "tx_search":
+		var params coretypes.RequestTxSearch
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		s.searches = append(s.searches, params)
+		fail := s.failures > 0
+		if fail {
+			s.failures--
+		}
+		s.mu.Unlock()
+
+		if fail {
+			http.Error(w, "Too Many Requests", http.StatusTooManyRequests)
+			return
+		}
+
+		result = s.search(params)
+	case "header":
+		var params coretypes.RequestBlockInfo
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		s.mu.Lock()
+		s.headers++
+		s.mu.Unlock()
+
+		result = &coretypes.ResultHeader{Header: &tmtypes.Header{
+			Height: int64(*params.Height),
+			Time:   time.Unix(int64(*params.Height), 0).UTC(),
+		}}
+	case "block", "block_results":
+		var params coretypes.RequestBlockInfo
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		result = s.block(req.Method, int64(*params.Height))
+	case "status":
+		s.mu.Lock()
+		result = &coretypes.ResultStatus{
+			NodeInfo: tmtypes.NodeInfo{Network: s.network},
+			SyncInfo: coretypes.SyncInfo{LatestBlockHeight: s.height, CatchingUp: s.catchingUp},
+		}
+		s.mu.Unlock()
+	default:
+		http.Error(w, "unknown method "+req.Method, http.StatusNotFound)
+		return
+	}
+
+	rawResult, err := json.Marshal(result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      req.ID,
+		"result":  json.RawMessage(rawResult),
+	})
+}
+
+func (s *stubRPC) search(params coretypes.RequestTxSearch) *coretypes.ResultTxSearch {
+	var from, to int64
+	if m := stubHeightRegexp.FindStringSubmatch(params.Query); m != nil {
+		from, _ = strconv.ParseInt(m[1], 10, 64)
+		to, _ = strconv.ParseInt(m[2], 10, 64)
+	}
+
+	var matched []*coretypes.ResultTx
+	for _, tx := range s.txs {
+		if tx.Height >= from && tx.Height <= to {
+			matched = append(matched, tx)
+		}
+	}
+
+	page, perPage := 1, 30
+	if params.Page != nil {
+		page = int(*params.Page)
+	}
+	if params.PerPage != nil {
+		perPage = int(*params.PerPage)
+	}
+
+	start := min((page-1)*perPage, len(matched))
+	end := min(start+perPage, len(matched))
+
+	return &coretypes.ResultTxSearch{Txs: matched[start:end], TotalCount: len(matched)}
+}
+
+// block returns block or block results at the height with the txs of the height
+func (s *stubRPC) block(method string, height int64) interface{} {
+	block := &tmtypes.Block{Header: tmtypes.Header{Height: height, Time: time.Unix(height, 0).UTC()}}
+	results := &coretypes.ResultBlockResults{Height: height}
+	for _, tx := range s.txs {
+		if tx.Height == height {
+			block.Txs = append(block.Txs, tx.Tx)
+			txResult := tx.TxResult
+			results.TxsResults = append(results.TxsResults, &txResult)
+		}
+	}
+
+	if method == "block_results" {
+		return results
+	}
+	return &coretypes.ResultBlock{Block: block}
+}
+
+// setHeight sets latest height reported by status
+func (s *stubRPC) setHeight(height int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.height = height
+}
+
+func (s *stubRPC) searchCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.searches)
+}
+
+// newStubClient creates client talking to the stub without going through NewClient
+func newStubClient(t *testing.T, s *stubRPC) *Client {
+	rpc, err := client.NewClientFromNode(s.server.URL)
+	assert.NilError(t, err)
+
+	interfaceRegistry := codecTypes.NewInterfaceRegistry()
+	std.RegisterInterfaces(interfaceRegistry)
+	wasmtypes.RegisterInterfaces(interfaceRegistry)
+
+	return &Client{
+		clientCtx: client.Context{}.
+			WithClient(rpc).
+			WithInterfaceRegistry(interfaceRegistry).
+			WithTxConfig(tx.NewTxConfig(codec.NewProtoCodec(interfaceRegistry), []signing.SignMode{signing.SignMode_SIGN_MODE_DIRECT})),
+	}
+}
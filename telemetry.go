@@ -0,0 +1,276 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	tracerName       = "github.com/spell-club/sei-sdk"
+	metricsNamespace = "sei_sdk"
+
+	transportGRPC = "grpc"
+	transportRPC  = "rpc"
+)
+
+// TelemetryConfig configures tracing and metrics of the client
+type TelemetryConfig struct {
+	// TracerProvider creates spans of gRPC and RPC requests and of tx broadcast stages, the global provider by default
+	TracerProvider trace.TracerProvider
+	// Registerer registers Prometheus metrics of the client, metrics are not collected when it is nil.
+	// Clients sharing the registerer share the metrics
+	Registerer prometheus.Registerer
+}
+
+// telemetry traces, measures and logs requests of the client, nil telemetry does nothing
+type telemetry struct {
+	tracer  trace.Tracer
+	metrics *metrics
+	logger  *slog.Logger
+}
+
+// metrics are Prometheus metrics of the client
+type metrics struct {
+	requestDuration *prometheus.HistogramVec
+	requests        *prometheus.CounterVec
+	gasUsed         prometheus.Histogram
+	feesPaid        *prometheus.CounterVec
+	sequenceResyncs prometheus.Counter
+}
+
+func newTelemetry(cfg TelemetryConfig, logger *slog.Logger) (*telemetry, error) {
+	provider := cfg.TracerProvider
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+
+	t := &telemetry{tracer: provider.Tracer(tracerName), logger: logger}
+	if cfg.Registerer != nil {
+		var err error
+		t.metrics, err = newMetrics(cfg.Registerer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func newMetrics(r prometheus.Registerer) (m *metrics, err error) {
+	m = &metrics{}
+	m.requestDuration, err = register(r, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "request_duration_seconds",
+		Help:      "Duration of gRPC and RPC requests sent to the endpoints, every failover and retry attempt is observed",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"transport", "method", "endpoint"}))
+	if err != nil {
+		return nil, err
+	}
+	m.requests, err = register(r, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "requests_total",
+		Help:      "gRPC and RPC requests sent to the endpoints by result code",
+	}, []string{"transport", "method", "endpoint", "code"}))
+	if err != nil {
+		return nil, err
+	}
+	m.gasUsed, err = register(r, prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "tx_gas_used",
+		Help:      "Gas used by tx simulations",
+		Buckets:   prometheus.ExponentialBuckets(50_000, 2, 10),
+	}))
+	if err != nil {
+		return nil, err
+	}
+	m.feesPaid, err = register(r, prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "tx_fees_paid_total",
+		Help:      "Fees of the txs accepted by the nodes",
+	}, []string{"denom"}))
+	if err != nil {
+		return nil, err
+	}
+	m.sequenceResyncs, err = register(r, prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "sequence_resyncs_total",
+		Help:      "Txs rejected with account sequence mismatch, the sequence is reloaded from the chain for the next tx",
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// register registers the collector or returns the collector registered by another client
+func register[T prometheus.Collector](r prometheus.Registerer, c T) (T, error) {
+	err := r.Register(c)
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing, nil
+		}
+	}
+	if err != nil {
+		return c, fmt.Errorf("prometheus.Register: %w", err)
+	}
+
+	return c, nil
+}
+
+// start starts span of the client, the span does nothing when telemetry is nil
+func (t *telemetry) start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if t == nil {
+		return noop.NewTracerProvider().Tracer(tracerName).Start(ctx, name)
+	}
+
+	return t.tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// observe traces and measures request to the endpoint
+func (t *telemetry) observe(ctx context.Context, transport, method, endpoint string, request func(ctx context.Context) error) error {
+	if t == nil {
+		return request(ctx)
+	}
+
+	ctx, span := t.tracer.Start(ctx, method, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("rpc.system", transport),
+		attribute.String("rpc.method", method),
+		attribute.String("server.address", endpoint),
+	))
+	defer span.End()
+
+	start := time.Now()
+	err := request(ctx)
+	code := errorCode(err)
+	span.SetAttributes(attribute.String("sei.code", code))
+	setSpanError(span, err)
+
+	duration := time.Since(start)
+	t.logger.DebugContext(ctx, "request", "transport", transport, "method", method, "endpoint", endpoint, "code", code, "duration", duration)
+	if t.metrics != nil {
+		t.metrics.requestDuration.WithLabelValues(transport, method, endpoint).Observe(duration.Seconds())
+		t.metrics.requests.WithLabelValues(transport, method, endpoint, code).Inc()
+	}
+
+	return err
+}
+
+// unaryInterceptor traces and measures requests to the endpoint, RPC requests are intercepted by poolRPC
+func (t *telemetry) unaryInterceptor(transport, endpoint string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return t.observe(ctx, transport, method, endpoint, func(ctx context.Context) error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+	}
+}
+
+// observeGas records gas used by the tx simulation
+func (t *telemetry) observeGas(gasUsed uint64) {
+	if t == nil || t.metrics == nil {
+		return
+	}
+
+	t.metrics.gasUsed.Observe(float64(gasUsed))
+}
+
+// observeBroadcast records fees of the accepted tx or the sequence resync of the rejected tx
+func (t *telemetry) observeBroadcast(fees sdktypes.Coins, err error) {
+	if t == nil || t.metrics == nil {
+		return
+	}
+
+	if err != nil {
+		if strings.Contains(err.Error(), ErrAccountSequenceMismatch) {
+			t.metrics.sequenceResyncs.Inc()
+		}
+		return
+	}
+	for _, fee := range fees {
+		amount, _ := new(big.Float).SetInt(fee.Amount.BigInt()).Float64()
+		t.metrics.feesPaid.WithLabelValues(fee.Denom).Add(amount)
+	}
+}
+
+// setSpanError records the error of the span
+func setSpanError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+}
+
+// endSpan records the error of the span and ends it
+func endSpan(span trace.Span, err error) {
+	setSpanError(span, err)
+	span.End()
+}
+
+// errorCode returns label of the request result: gRPC status code, HTTP status of the proxy or JSON-RPC error
+func errorCode(err error) string {
+	var (
+		rpcErr  *rpctypes.RPCError
+		httpErr *httpStatusError
+	)
+	switch {
+	case err == nil:
+		return "OK"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "DeadlineExceeded"
+	case errors.Is(err, context.Canceled):
+		return "Canceled"
+	case errors.As(err, &rpcErr):
+		return "RPCError"
+	case errors.As(err, &httpErr):
+		return "HTTP" + strconv.Itoa(httpErr.StatusCode)
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Code().String()
+	}
+
+	return "Unknown"
+}
+
+// txAttributes returns span attributes of the signer and the contracts of the messages
+func txAttributes(signer string, msgs []sdktypes.Msg) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{attribute.String("sei.signer", signer)}
+	var contracts []string
+	for _, msg := range msgs {
+		switch msg := msg.(type) {
+		case *wasmtypes.MsgExecuteContract:
+			contracts = append(contracts, msg.Contract)
+		case *wasmtypes.MsgInstantiateContract:
+			attrs = append(attrs, attribute.Int64("sei.code_id", int64(msg.CodeID)))
+		}
+	}
+	if len(contracts) > 0 {
+		attrs = append(attrs, attribute.StringSlice("sei.contracts", contracts))
+	}
+
+	return attrs
+}
+
+// heightAttributes returns span attributes of the contract and the height range of tx search
+func heightAttributes(contract string, from, to int64) []attribute.KeyValue {
+	return []attribute.KeyValue{attribute.String("sei.contract", contract), attribute.Int64("sei.height_from", from), attribute.Int64("sei.height_to", to)}
+}
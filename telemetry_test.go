@@ -0,0 +1,197 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	codecTypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	abci "github.com/tendermint/tendermint/abci/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func newTestTelemetry(t *testing.T) (*telemetry, *tracetest.SpanRecorder) {
+	recorder := tracetest.NewSpanRecorder()
+	tel, err := newTelemetry(TelemetryConfig{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		Registerer:     prometheus.NewRegistry(),
+	}, newLogger(Config{}))
+	assert.NilError(t, err)
+
+	return tel, recorder
+}
+
+func TestTelemetry_RPC(t *testing.T) {
+	stub := newStubRPC(t)
+	stub.network = string(ChainIDTestnet)
+	recorder := tracetest.NewSpanRecorder()
+	cfg := stubConfig(stub)
+	cfg.Telemetry = TelemetryConfig{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+		Registerer:     prometheus.NewRegistry(),
+	}
+	c, err := NewClient(cfg)
+	assert.NilError(t, err)
+	defer c.Close()
+
+	assert.NilError(t, c.Ping(context.Background()))
+	assert.Equal(t, testutil.ToFloat64(c.telemetry.metrics.requests.WithLabelValues(transportRPC, "status", stub.server.URL, "OK")), 1.0)
+	assert.Equal(t, testutil.CollectAndCount(c.telemetry.metrics.requestDuration), 1)
+
+	spans := recorder.Ended()
+	assert.Equal(t, len(spans), 1)
+	assert.Equal(t, spans[0].Name(), "status")
+	assert.Assert(t, hasAttribute(spans[0].Attributes(), attribute.String("server.address", stub.server.URL)))
+}
+
+func TestTelemetry_GRPCInterceptor(t *testing.T) {
+	tel, recorder := newTestTelemetry(t)
+	interceptor := tel.unaryInterceptor(transportGRPC, "node:9090")
+	invoker := func(context.Context, string, any, any, *grpc.ClientConn, ...grpc.CallOption) error {
+		return status.Error(codes.Unavailable, "down")
+	}
+
+	err := interceptor(context.Background(), broadcastTxMethod, nil, nil, nil, invoker)
+	assert.Equal(t, status.Code(err), codes.Unavailable)
+	assert.Equal(t, testutil.ToFloat64(tel.metrics.requests.WithLabelValues(transportGRPC, broadcastTxMethod, "node:9090", "Unavailable")), 1.0)
+
+	spans := recorder.Ended()
+	assert.Equal(t, len(spans), 1)
+	assert.Equal(t, spans[0].Name(), broadcastTxMethod)
+	assert.Equal(t, spans[0].Status().Description, "rpc error: code = Unavailable desc = down")
+}
+
+func TestTelemetry_Broadcast(t *testing.T) {
+	tel, _ := newTestTelemetry(t)
+
+	tel.observeGas(120_000)
+	tel.observeBroadcast(sdktypes.NewCoins(sdktypes.NewCoin(DefaultDenom, sdktypes.NewInt(13_200))), nil)
+	tel.observeBroadcast(sdktypes.NewCoins(sdktypes.NewCoin(DefaultDenom, sdktypes.NewInt(13_200))), nil)
+	tel.observeBroadcast(nil, fmt.Errorf("RawLog: %s, expected 5, got 4", ErrAccountSequenceMismatch))
+	tel.observeBroadcast(nil, errors.New("out of gas"))
+
+	assert.Equal(t, testutil.CollectAndCount(tel.metrics.gasUsed), 1)
+	assert.Equal(t, testutil.ToFloat64(tel.metrics.feesPaid.WithLabelValues(DefaultDenom)), 26_400.0)
+	assert.Equal(t, testutil.ToFloat64(tel.metrics.sequenceResyncs), 1.0)
+}
+
+func TestTelemetry_SharedRegisterer(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	first, err := newTelemetry(TelemetryConfig{Registerer: registry}, newLogger(Config{}))
+	assert.NilError(t, err)
+	second, err := newTelemetry(TelemetryConfig{Registerer: registry}, newLogger(Config{}))
+	assert.NilError(t, err)
+
+	first.metrics.sequenceResyncs.Inc()
+	second.metrics.sequenceResyncs.Inc()
+	assert.Equal(t, testutil.ToFloat64(first.metrics.sequenceResyncs), 2.0)
+}
+
+func TestTelemetry_QuerySpans(t *testing.T) {
+	stub := newStubRPC(t, 5)
+	c := newStubClient(t, stub)
+	var recorder *tracetest.SpanRecorder
+	c.telemetry, recorder = newTestTelemetry(t)
+
+	err := c.HandleTxsByHeight(context.Background(), testContractAddress, 1, 10, func(context.Context, []abci.Event) error { return nil })
+	assert.NilError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = c.GetBlock(ctx, 5)
+	assert.Assert(t, errors.Is(err, context.Canceled))
+
+	spans := recorder.Ended()
+	assert.Equal(t, len(spans), 2)
+	assert.Equal(t, spans[0].Name(), "sei.HandleTxsByHeight")
+	assert.Assert(t, hasAttribute(spans[0].Attributes(), attribute.String("sei.contract", testContractAddress)))
+	assert.Assert(t, hasAttribute(spans[0].Attributes(), attribute.Int64("sei.height_to", 10)))
+	assert.Equal(t, spans[1].Name(), "sei.GetBlock")
+	assert.Equal(t, spans[1].Status().Code, otelcodes.Error)
+}
+
+// accountConn answers account queries, recording span contexts of the requests
+type accountConn struct {
+	fakeConn
+	spans []trace.SpanContext
+}
+
+func (a *accountConn) Invoke(ctx context.Context, _ string, _, reply any, _ ...grpc.CallOption) error {
+	a.spans = append(a.spans, trace.SpanContextFromContext(ctx))
+	account, err := codecTypes.NewAnyWithValue(&authtypes.BaseAccount{AccountNumber: 7, Sequence: 3})
+	if err != nil {
+		return err
+	}
+	reply.(*authtypes.QueryAccountResponse).Account = account
+
+	return nil
+}
+
+func TestTelemetry_AccountSequenceSpan(t *testing.T) {
+	tel, recorder := newTestTelemetry(t)
+	interfaceRegistry := codecTypes.NewInterfaceRegistry()
+	authtypes.RegisterInterfaces(interfaceRegistry)
+	conn := &accountConn{}
+	c := &Client{
+		clientCtx:       client.Context{}.WithInterfaceRegistry(interfaceRegistry),
+		authQueryClient: authtypes.NewQueryClient(conn),
+		telemetry:       tel,
+	}
+
+	num, seq, err := c.accountNumberSequence(context.Background(), sdktypes.AccAddress("address_____________"))
+	assert.NilError(t, err)
+	assert.Equal(t, num, uint64(7))
+	assert.Equal(t, seq, uint64(3))
+
+	// the account is queried within the span
+	spans := recorder.Ended()
+	assert.Equal(t, len(spans), 1)
+	assert.Equal(t, spans[0].Name(), "sei.accountSequence")
+	assert.Equal(t, len(conn.spans), 1)
+	assert.Equal(t, conn.spans[0].SpanID(), spans[0].SpanContext().SpanID())
+}
+
+func TestTelemetry_Nil(t *testing.T) {
+	var tel *telemetry
+	_, span := tel.start(context.Background(), "sei.broadcastTx")
+	span.End()
+	assert.NilError(t, tel.observe(context.Background(), transportRPC, "status", "", func(context.Context) error { return nil }))
+	tel.observeBroadcast(nil, errors.New(ErrAccountSequenceMismatch))
+}
+
+func TestErrorCode(t *testing.T) {
+	for err, code := range map[error]string{
+		nil:                                  "OK",
+		context.DeadlineExceeded:             "DeadlineExceeded",
+		status.Error(codes.NotFound, "tx"):   "NotFound",
+		&rpctypes.RPCError{Code: -32603}:     "RPCError",
+		&httpStatusError{StatusCode: 429}:    "HTTP429",
+		fmt.Errorf("x: %w", errors.New("?")): "Unknown",
+	} {
+		assert.Equal(t, errorCode(err), code, "%v", err)
+	}
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, a := range attrs {
+		if a == want {
+			return true
+		}
+	}
+
+	return false
+}
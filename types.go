@@ -1,5 +1,9 @@
 package sdk
 
+import (
+	"errors"
+)
+
 type SubscribeMessage struct {
 	Result struct {
 		Events Events `json:"events"`
@@ -15,3 +19,69 @@ type Events struct {
 	WasmReferralAddr    []string `json:"wasm.referral_addr"`
 	WasmReferralAmount  []string `json:"wasm.referral_amount"`
 }
+
+// HeightRange is a range of block heights. Both bounds are inclusive unless excluded explicitly
+type HeightRange struct {
+	From int64
+	To   int64
+
+	ExcludeFrom bool
+	ExcludeTo   bool
+}
+
+// Bounds returns inclusive bounds of the range
+func (r HeightRange) Bounds() (from, to int64) {
+	from, to = r.From, r.To
+	if r.ExcludeFrom {
+		from++
+	}
+	if r.ExcludeTo {
+		to--
+	}
+
+	return from, to
+}
+
+// Validate validates that the range is not empty. From 0 is accepted and matches txs from the first block
+func (r HeightRange) Validate() error {
+	if r.From < 0 {
+		return errors.New("negative From")
+	}
+
+	from, to := r.Bounds()
+	if to < from {
+		return errors.New("empty height range")
+	}
+
+	return nil
+}
+
+// TxSearchConfig configures sequential tx search by height
+type TxSearchConfig struct {
+	ContractAddress string
+	Heights         HeightRange
+
+	// PageSize is the TxSearch page size, DefaultTxSearchPageSize is used by default
+	PageSize int
+	// WindowSize is the amount of heights covered by a single TxSearch query, DefaultTxSearchWindowSize is used by default
+	WindowSize int64
+}
+
+// Validate validates tx search config and fills defaults
+func (cfg *TxSearchConfig) Validate() error {
+	if cfg.ContractAddress == "" {
+		return errors.New("empty ContractAddress")
+	}
+
+	err := cfg.Heights.Validate()
+	if err != nil {
+		return err
+	}
+
+	cfg.PageSize = txSearchPageSize(cfg.PageSize)
+	if cfg.WindowSize <= 0 {
+		cfg.WindowSize = DefaultTxSearchWindowSize
+	}
+
+	return nil
+}
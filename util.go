@@ -2,9 +2,10 @@ package sdk
 
 import "github.com/cosmos/cosmos-sdk/types/bech32"
 
+// IsValidBlockchainAddress reports whether the address is an account address with the prefix of the clients
 func IsValidBlockchainAddress(address string) bool {
 	hrp, _, err := bech32.DecodeAndConvert(address)
-	if err != nil || hrp != Bech32PrefixAccAddr {
+	if err != nil || hrp != accountPrefix() {
 		return false
 	}
 
@@ -5,16 +5,23 @@ import (
 
 	wasmtypes "github.com/CosmWasm/wasmd/x/wasm/types"
 	"github.com/cosmos/cosmos-sdk/types/query"
+	"go.opentelemetry.io/otel/attribute"
 )
 
-func (c *Client) FetchContractInfo(ctx context.Context, address string) (*wasmtypes.QueryContractInfoResponse, error) {
+func (c *Client) FetchContractInfo(ctx context.Context, address string) (res *wasmtypes.QueryContractInfoResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.FetchContractInfo", attribute.String("sei.contract", address))
+	defer func() { endSpan(span, err) }()
+
 	req := &wasmtypes.QueryContractInfoRequest{
 		Address: address,
 	}
 	return c.wasmQueryClient.ContractInfo(ctx, req)
 }
 
-func (c *Client) FetchContractHistory(ctx context.Context, address string, pagination *query.PageRequest) (*wasmtypes.QueryContractHistoryResponse, error) {
+func (c *Client) FetchContractHistory(ctx context.Context, address string, pagination *query.PageRequest) (res *wasmtypes.QueryContractHistoryResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.FetchContractHistory", attribute.String("sei.contract", address))
+	defer func() { endSpan(span, err) }()
+
 	req := &wasmtypes.QueryContractHistoryRequest{
 		Address:    address,
 		Pagination: pagination,
@@ -22,7 +29,10 @@ func (c *Client) FetchContractHistory(ctx context.Context, address string, pagin
 	return c.wasmQueryClient.ContractHistory(ctx, req)
 }
 
-func (c *Client) FetchContractsByCode(ctx context.Context, codeID uint64, pagination *query.PageRequest) (*wasmtypes.QueryContractsByCodeResponse, error) {
+func (c *Client) FetchContractsByCode(ctx context.Context, codeID uint64, pagination *query.PageRequest) (res *wasmtypes.QueryContractsByCodeResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.FetchContractsByCode", attribute.Int64("sei.code_id", int64(codeID)))
+	defer func() { endSpan(span, err) }()
+
 	req := &wasmtypes.QueryContractsByCodeRequest{
 		CodeId:     codeID,
 		Pagination: pagination,
@@ -30,7 +40,10 @@ func (c *Client) FetchContractsByCode(ctx context.Context, codeID uint64, pagina
 	return c.wasmQueryClient.ContractsByCode(ctx, req)
 }
 
-func (c *Client) FetchAllContractsState(ctx context.Context, address string, pagination *query.PageRequest) (*wasmtypes.QueryAllContractStateResponse, error) {
+func (c *Client) FetchAllContractsState(ctx context.Context, address string, pagination *query.PageRequest) (res *wasmtypes.QueryAllContractStateResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.FetchAllContractsState", attribute.String("sei.contract", address))
+	defer func() { endSpan(span, err) }()
+
 	req := &wasmtypes.QueryAllContractStateRequest{
 		Address:    address,
 		Pagination: pagination,
@@ -38,7 +51,10 @@ func (c *Client) FetchAllContractsState(ctx context.Context, address string, pag
 	return c.wasmQueryClient.AllContractState(ctx, req)
 }
 
-func (c *Client) RawContractState(ctx context.Context, contractAddress string, queryData []byte) (*wasmtypes.QueryRawContractStateResponse, error) {
+func (c *Client) RawContractState(ctx context.Context, contractAddress string, queryData []byte) (res *wasmtypes.QueryRawContractStateResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.RawContractState", attribute.String("sei.contract", contractAddress))
+	defer func() { endSpan(span, err) }()
+
 	return c.wasmQueryClient.RawContractState(
 		ctx,
 		&wasmtypes.QueryRawContractStateRequest{
@@ -48,7 +64,10 @@ func (c *Client) RawContractState(ctx context.Context, contractAddress string, q
 	)
 }
 
-func (c *Client) SmartContractState(ctx context.Context, contractAddress string, queryData []byte) (*wasmtypes.QuerySmartContractStateResponse, error) {
+func (c *Client) SmartContractState(ctx context.Context, contractAddress string, queryData []byte) (res *wasmtypes.QuerySmartContractStateResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.SmartContractState", attribute.String("sei.contract", contractAddress))
+	defer func() { endSpan(span, err) }()
+
 	return c.wasmQueryClient.SmartContractState(
 		ctx,
 		&wasmtypes.QuerySmartContractStateRequest{
@@ -58,21 +77,30 @@ func (c *Client) SmartContractState(ctx context.Context, contractAddress string,
 	)
 }
 
-func (c *Client) FetchCode(ctx context.Context, codeID uint64) (*wasmtypes.QueryCodeResponse, error) {
+func (c *Client) FetchCode(ctx context.Context, codeID uint64) (res *wasmtypes.QueryCodeResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.FetchCode", attribute.Int64("sei.code_id", int64(codeID)))
+	defer func() { endSpan(span, err) }()
+
 	req := &wasmtypes.QueryCodeRequest{
 		CodeId: codeID,
 	}
 	return c.wasmQueryClient.Code(ctx, req)
 }
 
-func (c *Client) FetchCodes(ctx context.Context, pagination *query.PageRequest) (*wasmtypes.QueryCodesResponse, error) {
+func (c *Client) FetchCodes(ctx context.Context, pagination *query.PageRequest) (res *wasmtypes.QueryCodesResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.FetchCodes")
+	defer func() { endSpan(span, err) }()
+
 	req := &wasmtypes.QueryCodesRequest{
 		Pagination: pagination,
 	}
 	return c.wasmQueryClient.Codes(ctx, req)
 }
 
-func (c *Client) FetchPinnedCodes(ctx context.Context, pagination *query.PageRequest) (*wasmtypes.QueryPinnedCodesResponse, error) {
+func (c *Client) FetchPinnedCodes(ctx context.Context, pagination *query.PageRequest) (res *wasmtypes.QueryPinnedCodesResponse, err error) {
+	ctx, span := c.telemetry.start(ctx, "sei.FetchPinnedCodes")
+	defer func() { endSpan(span, err) }()
+
 	req := &wasmtypes.QueryPinnedCodesRequest{
 		Pagination: pagination,
 	}